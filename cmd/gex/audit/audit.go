@@ -0,0 +1,159 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/filepath/pkg/filepath"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+const LOGFILE = "audit.log"
+
+type Record struct {
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`
+	Garden  string    `json:"garden"`
+	Element string    `json:"element"`
+	Action  string    `json:"action"`
+	Name    string    `json:"name,omitempty"`
+}
+
+type Log struct {
+	lock   sync.Mutex
+	path   string
+	garden string
+	user   string
+}
+
+var log *Log
+
+// Setup enables the audit log for the actual context and registers
+// it for all credential accesses done by the garden objects.
+func Setup(ctx *context.Context) {
+	if ctx.Gexdir == "" {
+		return
+	}
+	log = NewLog(filepath.Join(ctx.Gexdir, LOGFILE), ctx.Name)
+	gube.SetAccessRecorder(func(elem interface{}, kind string, name string) {
+		Audit(elem, kind, name)
+	})
+}
+
+func GetLog() *Log {
+	return log
+}
+
+func NewLog(path string, garden string) *Log {
+	name := "unknown"
+	usr, err := user.Current()
+	if err == nil {
+		name = usr.Username
+	}
+	return &Log{path: path, garden: garden, user: name}
+}
+
+func (this *Log) GetPath() string {
+	return this.path
+}
+
+// GardenName provides the name of the garden an element belongs to.
+// Elements without garden are recorded for the garden selected
+// for the log.
+func (this *Log) GardenName(elem interface{}) string {
+	switch e := elem.(type) {
+	case gube.GardenConfig:
+		return e.GetName()
+	case gube.GardenObject:
+		if g := e.Garden(); g != nil && g.GetName() != "" {
+			return g.GetName()
+		}
+	}
+	return this.garden
+}
+
+func (this *Log) Record(elem interface{}, action string, name string) error {
+	r := &Record{
+		Time:    time.Now(),
+		User:    this.user,
+		Garden:  this.GardenName(elem),
+		Element: ElementName(elem),
+		Action:  action,
+		Name:    name,
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	err = os.MkdirAll(filepath.Dir2(this.path), 0700)
+	if err != nil {
+		return fmt.Errorf("cannot create audit log dir: %s", err)
+	}
+	f, err := os.OpenFile(this.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot open audit log '%s': %s", this.path, err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("cannot write audit log '%s': %s", this.path, err)
+	}
+	return nil
+}
+
+func (this *Log) Records() ([]*Record, error) {
+	f, err := os.Open(this.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Record{}, nil
+		}
+		return nil, fmt.Errorf("cannot open audit log '%s': %s", this.path, err)
+	}
+	defer f.Close()
+	records := []*Record{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		r := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+// Audit records an access to credentials or a privileged action
+// for the given element in the audit log, if configured.
+func Audit(elem interface{}, action string, name string) {
+	if log == nil {
+		return
+	}
+	if err := log.Record(elem, action, name); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+	}
+}
+
+func ElementName(elem interface{}) string {
+	switch e := elem.(type) {
+	case gube.Shoot:
+		return "shoot " + e.GetName().String()
+	case gube.Seed:
+		return "seed " + e.GetName()
+	case gube.GardenConfig:
+		return "garden " + e.GetName()
+	case gube.Cluster:
+		return e.GetClusterKey()
+	case nil:
+		return ""
+	}
+	return fmt.Sprintf("%v", elem)
+}
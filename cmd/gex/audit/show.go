@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+)
+
+var cmdtab cmdint.ConfigurableCmdTab = cmdint.NewCmdTab("audit", nil).
+	CmdDescription("local audit log\n" +
+		"query the log of credential accesses and privileged actions").
+	CmdArgDescription("<command>")
+
+func init() {
+	cmdint.MainTab().
+		Command("audit", cmdtab)
+
+	cmdtab.SimpleCommand("show", show).
+		CmdDescription("show audit log records",
+			"The optional arguments are matched against the element names.",
+		).
+		CmdArgDescription("{<element>}").
+		ArgOption(constants.O_SINCE).Short('s').ArgDescription("<duration>").Description("show records of given time period, only (e.g. 24h)").
+		ArgOption(constants.O_ACTION).Short('a').ArgDescription("<action>").Description("show records for given action, only").
+		ArgOption(constants.O_USER).Short('u').ArgDescription("<user>").Description("show records for given user, only").
+		ArgOption(constants.O_GARDEN).Short('g').ArgDescription("<garden>").Description("show records for given garden, only")
+}
+
+func show(opts *cmdint.Options) error {
+	log := GetLog()
+	if log == nil {
+		return fmt.Errorf("No GEXDIR set")
+	}
+	var since time.Time
+	if v := opts.GetOptionValue(constants.O_SINCE); v != nil {
+		d, err := time.ParseDuration(*v)
+		if err != nil {
+			return fmt.Errorf("invalid duration '%s': %s", *v, err)
+		}
+		since = time.Now().Add(-d)
+	}
+	action := util.StringValue(opts.GetOptionValue(constants.O_ACTION))
+	user := util.StringValue(opts.GetOptionValue(constants.O_USER))
+	garden := util.StringValue(opts.GetOptionValue(constants.O_GARDEN))
+
+	records, err := log.Records()
+	if err != nil {
+		return err
+	}
+	table := [][]string{[]string{"TIME", "USER", "GARDEN", "ELEMENT", "ACTION", "NAME"}}
+	for _, r := range records {
+		if r.Time.Before(since) ||
+			(action != "" && r.Action != action) ||
+			(user != "" && r.User != user) ||
+			(garden != "" && r.Garden != garden) ||
+			!matchElement(r.Element, opts.Arguments) {
+			continue
+		}
+		table = append(table, []string{r.Time.Format(time.RFC3339), r.User, r.Garden, r.Element, r.Action, r.Name})
+	}
+	util.FormatTable("", table)
+	return nil
+}
+
+func matchElement(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if strings.Contains(name, p) {
+			return true
+		}
+	}
+	return false
+}
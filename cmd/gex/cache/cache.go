@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+	"github.com/afritzler/garden-examiner/pkg/data"
+)
+
+var cmdtab cmdint.ConfigurableCmdTab = cmdint.NewCmdTab("cache", nil).
+	CmdDescription("persistent garden cache\n" +
+		"The element lists of a garden are cached under the gex dir, if\n" +
+		"the gex config contains a cache section. The time to live can be\n" +
+		"configured per element type (shoots, projects, profiles).").
+	CmdArgDescription("<command>")
+
+func init() {
+	cmdint.MainTab().
+		Command("cache", cmdtab)
+
+	cmdtab.SimpleCommand("stats", stats).
+		CmdDescription("show cache state",
+			"Without arguments the cache of the selected garden is shown.",
+		).
+		CmdArgDescription("{<garden>}").
+		FlagOption(constants.O_ALL).Short('a').Description("show caches of all gardens")
+
+	cmdtab.SimpleCommand("clear", clear_cache).
+		CmdDescription("clear cache",
+			"Without arguments the cache of the selected garden is cleared.",
+		).
+		CmdArgDescription("{<garden>}").
+		FlagOption(constants.O_ALL).Short('a').Description("clear caches of all gardens")
+}
+
+func gardens(ctx *context.Context, opts *cmdint.Options) ([]string, error) {
+	if ctx.Gexdir == "" {
+		return nil, fmt.Errorf("No GEXDIR set")
+	}
+	if opts.IsFlag(constants.O_ALL) {
+		if len(opts.Arguments) > 0 {
+			return nil, fmt.Errorf("no garden names possible for option --%s", constants.O_ALL)
+		}
+		return ctx.GardenSetConfig.GetNames(), nil
+	}
+	if len(opts.Arguments) > 0 {
+		for _, n := range opts.Arguments {
+			if _, err := ctx.GardenSetConfig.GetConfig(n); err != nil {
+				return nil, err
+			}
+		}
+		return opts.Arguments, nil
+	}
+	return []string{ctx.Name}, nil
+}
+
+func stores(ctx *context.Context, garden string) (map[string]data.CacheStore, error) {
+	cfg := ctx.GardenSetConfig.GetCacheConfig()
+	if cfg == nil {
+		cfg = &gube.CacheConfig{}
+	}
+	return gube.NewCacheStores(nil, ctx.ElementCacheDir(garden), cfg, false)
+}
+
+func stats(opts *cmdint.Options) error {
+	ctx := context.Get(opts)
+	names, err := gardens(ctx, opts)
+	if err != nil {
+		return err
+	}
+	enabled := ctx.GardenSetConfig.GetCacheConfig() != nil
+	table := [][]string{[]string{"GARDEN", "TYPE", "-ELEMENTS", "-AGE", "-TTL", "STATE"}}
+	for _, n := range names {
+		s, err := stores(ctx, n)
+		if err != nil {
+			return err
+		}
+		for _, kind := range gube.CacheTypes {
+			info, err := s[kind].Stat()
+			if err != nil {
+				table = append(table, []string{n, kind, "", "", "", err.Error()})
+				continue
+			}
+			count, age, state := "", "", "valid"
+			if !info.Empty() {
+				count = fmt.Sprintf("%d", info.Count)
+				age = info.Age().Round(time.Second).String()
+			}
+			switch {
+			case !enabled || info.TTL <= 0:
+				state = "disabled"
+			case info.Empty():
+				state = "empty"
+			case info.Expired():
+				state = "expired"
+			}
+			table = append(table, []string{n, kind, count, age, info.TTL.String(), state})
+		}
+	}
+	util.FormatTable("", table)
+	return nil
+}
+
+func clear_cache(opts *cmdint.Options) error {
+	ctx := context.Get(opts)
+	names, err := gardens(ctx, opts)
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		s, err := stores(ctx, n)
+		if err != nil {
+			return err
+		}
+		for kind, store := range s {
+			if err := store.Clear(); err != nil {
+				return fmt.Errorf("cannot clear %s cache of garden %s: %s", kind, n, err)
+			}
+		}
+		fmt.Printf("cache of garden %s cleared\n", n)
+	}
+	return nil
+}
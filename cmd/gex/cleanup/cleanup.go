@@ -49,3 +49,41 @@ func cleanup() {
 	}
 	cleanupRequests = []*cleanupRequest{}
 }
+
+var cancelRequests = []*cleanupRequest{}
+
+// Cancel registers a function stopping an ongoing operation. On an
+// interrupt the registered cancel functions are called instead of
+// terminating gex, so the operation can finish regularly. A further
+// interrupt terminates gex. The returned function unregisters the
+// cancel function.
+func Cancel(f func()) func() {
+	cf := &cleanupRequest{f}
+	lock.Lock()
+	defer lock.Unlock()
+	cancelRequests = append(cancelRequests, cf)
+	return func() {
+		lock.Lock()
+		defer lock.Unlock()
+		for i, p := range cancelRequests {
+			if p == cf {
+				cancelRequests = append(cancelRequests[0:i], cancelRequests[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// cancel calls and removes the registered cancel functions. It
+// returns whether there were any.
+func cancel() bool {
+	lock.Lock()
+	requests := cancelRequests
+	cancelRequests = []*cleanupRequest{}
+	lock.Unlock()
+
+	for _, cf := range requests {
+		cf.cleanup()
+	}
+	return len(requests) > 0
+}
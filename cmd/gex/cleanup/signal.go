@@ -39,6 +39,10 @@ func init() {
 				fmt.Println("Unknown signal.")
 			}
 
+			// stop ongoing operations cleanly, if possible
+			if (s == syscall.SIGINT || s == syscall.SIGTERM) && cancel() {
+				continue
+			}
 			cleanup()
 			os.Exit(1)
 		}
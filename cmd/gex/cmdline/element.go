@@ -20,6 +20,7 @@ type ElementTypeHandler interface {
 	GetAll(ctx *context.Context, opts *cmdint.Options) ([]interface{}, error)
 	GetFilter() util.Filter
 	GetDefault(opts *cmdint.Options) *string
+	Reset()
 }
 
 type Handler interface {
@@ -61,7 +62,16 @@ func ExecuteOutputRaw(option string, opts *cmdint.Options, o Output, impl Elemen
 	return NewStandardOutputHandler(o, impl).DoitRaw(option, opts)
 }
 
+// ExecuteOutputAll feeds all elements matching the filters into
+// the output.
+func ExecuteOutputAll(opts *cmdint.Options, o Output, impl ElementTypeHandler) error {
+	return NewStandardOutputHandler(o, impl).DoitAll(opts)
+}
+
 func ExecuteMode(opts *cmdint.Options, outs Outputs, impl ElementTypeHandler) error {
+	if opts.IsFlag(constants.O_WATCH) || opts.GetOptionValue(constants.O_WATCH_INTERVAL) != nil {
+		return Watch(opts, outs, impl)
+	}
 	o, err := outs.Create(opts)
 	if err != nil {
 		return err
@@ -76,6 +86,10 @@ func (this *StandardHandler) DoitRaw(option string, opts *cmdint.Options) error
 	return DoitRaw(option, opts, this)
 }
 
+func (this *StandardHandler) DoitAll(opts *cmdint.Options) error {
+	return doAll(context.Get(opts), opts, this, true)
+}
+
 func (this *StandardHandler) GetDefault(opts *cmdint.Options) *string {
 	return this.impl.GetDefault(opts)
 }
@@ -0,0 +1,175 @@
+package cmdline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	. "github.com/afritzler/garden-examiner/cmd/gex/output"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+// SelectorFilter is a generic filter for all element types matching
+// a kubernetes label selector against the element labels and a field
+// selector against the element data (see ElementData), e.g.
+// spec.cloud.region=eu-west-1 or gex.state!=Succeeded.
+// Element types without labels offer no label selector, for element
+// types without manifest field selectors are restricted to the
+// computed attributes.
+type SelectorFilter struct {
+	// NoLabels is set for element types without labels.
+	NoLabels bool
+	// NoManifest is set for element types without manifest.
+	NoManifest bool
+}
+
+var _ util.Filter = &SelectorFilter{}
+
+func (this *SelectorFilter) AddOptions(cmd cmdint.ConfigurableCmdTabCommand) cmdint.ConfigurableCmdTabCommand {
+	if !this.NoLabels {
+		cmd = cmd.ArgOption(constants.O_SELECTOR).Short('l').ArgDescription("<selector>").Description("label selector (e.g. purpose=test,costcenter in (a,b))")
+	}
+	if this.NoManifest {
+		return cmd.ArgOption(constants.O_FIELD_SELECTOR).ArgDescription("<selector>").Description("field selector for element attributes (e.g. " + ATTR_FIELD + ".name=dev)")
+	}
+	return cmd.ArgOption(constants.O_FIELD_SELECTOR).ArgDescription("<selector>").Description("field selector for element data (e.g. spec.cloud.region=eu-west-1)")
+}
+
+func (this *SelectorFilter) Match(ctx *context.Context, elem interface{}, opts *cmdint.Options) (bool, error) {
+	lsel, fsel, err := selectors(opts)
+	if err != nil {
+		return false, err
+	}
+	if lsel != nil && !lsel.Matches(labels.Set(element_labels(elem))) {
+		return false, nil
+	}
+	if fsel != nil {
+		selected := map[string]bool{}
+		for _, r := range fsel.Requirements() {
+			if strings.HasPrefix(r.Field, ATTR_FIELD+".") {
+				selected[strings.Split(r.Field, ".")[1]] = true
+			} else if this.NoManifest {
+				return false, fmt.Errorf("invalid field selector field '%s': only %s.<attribute> fields are supported", r.Field, ATTR_FIELD)
+			}
+		}
+		data, err := ElementData(elem, selected)
+		if err != nil {
+			return false, err
+		}
+		return fsel.Matches(element_fields(data)), nil
+	}
+	return true, nil
+}
+
+func selectors(opts *cmdint.Options) (labels.Selector, fields.Selector, error) {
+	var lsel labels.Selector
+	var fsel fields.Selector
+	var err error
+	if v := opts.GetOptionValue(constants.O_SELECTOR); v != nil {
+		lsel, err = labels.Parse(*v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid label selector '%s': %s", *v, err)
+		}
+	}
+	if v := opts.GetOptionValue(constants.O_FIELD_SELECTOR); v != nil {
+		fsel, err = fields.ParseSelector(*v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid field selector '%s': %s", *v, err)
+		}
+	}
+	return lsel, fsel, nil
+}
+
+func element_labels(elem interface{}) map[string]string {
+	switch e := elem.(type) {
+	case gube.RuntimeObjectWrapper:
+		if m, err := meta.Accessor(e.GetRuntimeObject()); err == nil {
+			return m.GetLabels()
+		}
+	case gube.Project:
+		return e.GetLabels()
+	}
+	return nil
+}
+
+// element_fields provides access to the fields of generic json data
+// by their dot separated path.
+type element_fields map[string]interface{}
+
+var _ fields.Fields = element_fields{}
+
+func (this element_fields) lookup(field string) (interface{}, bool) {
+	var v interface{} = map[string]interface{}(this)
+	for _, n := range strings.Split(field, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		v, ok = m[n]
+		if !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (this element_fields) Has(field string) bool {
+	_, ok := this.lookup(field)
+	return ok
+}
+
+func (this element_fields) Get(field string) string {
+	v, ok := this.lookup(field)
+	if !ok || v == nil {
+		return ""
+	}
+	return util.JSONValueString(v)
+}
+
+// server_fields are the fields supported by field selectors
+// for all resources of the garden.
+var server_fields = map[string]bool{
+	"metadata.name":      true,
+	"metadata.namespace": true,
+}
+
+// ListOptions provides the list options for the selectors given by
+// the options, which can be evaluated by the garden. Label selectors
+// are passed completely, field selectors only for metadata fields and
+// only if withFields is set. The selectors are checked again by the
+// SelectorFilter, so a partial selection is sufficient.
+// Without selectors or if filters are disabled nil is returned.
+func ListOptions(opts *cmdint.Options, withFields bool) (*metav1.ListOptions, error) {
+	if opts.IsFlag(constants.O_NOFILTER) || (len(opts.Arguments) == 1 && opts.Arguments[0] == "all") {
+		return nil, nil
+	}
+	lsel, fsel, err := selectors(opts)
+	if err != nil {
+		return nil, err
+	}
+	list := &metav1.ListOptions{}
+	if lsel != nil {
+		list.LabelSelector = lsel.String()
+	}
+	if fsel != nil && withFields {
+		terms := []string{}
+		for _, r := range fsel.Requirements() {
+			if server_fields[r.Field] {
+				terms = append(terms, fmt.Sprintf("%s%s%s", r.Field, r.Operator, fields.EscapeValue(r.Value)))
+			}
+		}
+		list.FieldSelector = strings.Join(terms, ",")
+	}
+	if list.LabelSelector == "" && list.FieldSelector == "" {
+		return nil, nil
+	}
+	return list, nil
+}
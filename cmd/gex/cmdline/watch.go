@@ -0,0 +1,239 @@
+package cmdline
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	. "github.com/afritzler/garden-examiner/cmd/gex/output"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+const DEFAULT_WATCH_INTERVAL = 10 * time.Second
+
+// watch_settle is the time waited for further change events
+// before the table is rendered again.
+const watch_settle = time.Second
+
+// EventSource is implemented by element type handlers able to report
+// changes of their elements in a garden (the empty name denotes the
+// selected garden). Those changes trigger an update of a watched
+// table in addition to the periodic refresh.
+type EventSource interface {
+	Watch(ctx *context.Context, garden string) (watch.Interface, error)
+}
+
+// AddWatchOptions adds the watch options. Options cannot have an
+// optional argument, therefore the interval is given by a separate
+// option, which implies --watch.
+func AddWatchOptions(cmd cmdint.ConfigurableCmdTabCommand) cmdint.ConfigurableCmdTabCommand {
+	return cmd.FlagOption(constants.O_WATCH).Short('w').Description("watch elements and re-render on changes").
+		ArgOption(constants.O_WATCH_INTERVAL).ArgDescription("<duration>").Description("refresh interval for watch (default 10s), implies --watch").
+		FlagOption(constants.O_WATCH_CHANGED).Description("show only changed rows when watching")
+}
+
+// watch_handler is a standard handler just collecting the table
+// rows instead of printing them.
+type watch_handler struct {
+	*StandardHandler
+	table *TableProcessingOutput
+	rows  [][]string
+}
+
+func (this *watch_handler) Out(ctx *context.Context) error {
+	rows, err := this.table.Rows()
+	this.rows = rows
+	return err
+}
+
+// Watch keeps the cached gardens and re-renders the table output
+// selected by the options periodically or on change events.
+// Rows changed since the last update are highlighted.
+func Watch(opts *cmdint.Options, outs Outputs, impl ElementTypeHandler) error {
+	ctx := context.Get(opts)
+	interval := DEFAULT_WATCH_INTERVAL
+	if v := opts.GetOptionValue(constants.O_WATCH_INTERVAL); v != nil {
+		d, err := time.ParseDuration(*v)
+		if err != nil {
+			return fmt.Errorf("invalid watch interval: %s", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("watch interval must be positive")
+		}
+		interval = d
+	}
+	args := opts.Arguments
+
+	// check output mode before starting the watch
+	o, err := outs.Create(opts)
+	if err != nil {
+		return err
+	}
+	table, ok := o.(*TableProcessingOutput)
+	if !ok || table.Format() != nil {
+		return fmt.Errorf("watch requires a table output mode")
+	}
+
+	var events <-chan struct{}
+	if src, ok := impl.(EventSource); ok {
+		events = watch_events(ctx, src, watch_gardens(ctx, args))
+	}
+
+	w := &watcher{terminal: util.IsTerminal(), changed: opts.IsFlag(constants.O_WATCH_CHANGED)}
+	for {
+		if table == nil {
+			o, err := outs.Create(opts)
+			if err != nil {
+				return err
+			}
+			table = o.(*TableProcessingOutput)
+		}
+		opts.Arguments = args
+		h := &watch_handler{StandardHandler: NewStandardOutputHandler(table, impl), table: table}
+		err := Doit(opts, h)
+		w.render(table.Header(), h.rows, table.StyleRow, err, interval)
+		table = nil
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-events:
+			timer.Stop()
+			settle(events)
+		}
+		ctx.ResetGardens()
+		impl.Reset()
+	}
+}
+
+// settle waits until no further events arrive within the settle time.
+func settle(events <-chan struct{}) {
+	for {
+		select {
+		case <-events:
+		case <-time.After(watch_settle):
+			return
+		}
+	}
+}
+
+// watch_gardens provides the gardens of the watched elements given by
+// their potentially qualified names. Without names the selected garden
+// is watched.
+func watch_gardens(ctx *context.Context, args []string) []string {
+	found := map[string]bool{}
+	gardens := []string{}
+	for _, a := range args {
+		garden, _ := gube.SplitQualifiedName(a)
+		if garden == "" {
+			garden = ctx.Name
+		}
+		if !found[garden] {
+			found[garden] = true
+			gardens = append(gardens, garden)
+		}
+	}
+	if len(gardens) == 0 {
+		gardens = append(gardens, ctx.Name)
+	}
+	return gardens
+}
+
+// watch_events provides a channel signaling change events of an event
+// source for the given gardens. If a watch ends it is restarted. On
+// errors no further events are signaled for a garden, only the periodic
+// refresh is done.
+func watch_events(ctx *context.Context, src EventSource, gardens []string) <-chan struct{} {
+	events := make(chan struct{}, 1)
+	for _, g := range gardens {
+		go func(garden string) {
+			for {
+				w, err := src.Watch(ctx, garden)
+				if err != nil {
+					return
+				}
+				for range w.ResultChan() {
+					select {
+					case events <- struct{}{}:
+					default:
+					}
+				}
+				w.Stop()
+			}
+		}(g)
+	}
+	return events
+}
+
+// highlight shows all cells of a row bold keeping their styles.
+func highlight(row []string) []string {
+	result := make([]string, len(row))
+	for i, c := range row {
+		result[i] = Styled(STYLE_BOLD, strings.Replace(c, "\033[0m", "\033[0;1m", -1))
+	}
+	return result
+}
+
+type watcher struct {
+	terminal bool
+	changed  bool
+	last     map[string]bool
+}
+
+// render prints the actual table. On a terminal the screen is cleared
+// and with styled output changed rows are shown bold, otherwise
+// the tables are appended.
+func (this *watcher) render(header []string, rows [][]string, style func([]string) []string, err error, interval time.Duration) {
+	current := map[string]bool{}
+	changed := make([]bool, len(rows))
+	for i, r := range rows {
+		key := strings.Join(r, "\x00")
+		current[key] = true
+		changed[i] = this.last != nil && !this.last[key]
+	}
+
+	buf := &bytes.Buffer{}
+	if this.terminal {
+		fmt.Fprint(buf, "\033[H\033[2J")
+	} else if this.last != nil {
+		fmt.Fprintln(buf)
+	}
+	fmt.Fprintf(buf, "Every %s: %s\n\n", interval, time.Now().Format("2006-01-02 15:04:05"))
+
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = len(strings.TrimPrefix(h, "-"))
+	}
+	for _, r := range rows {
+		for i, c := range r {
+			if l := util.VisibleLen(c); i < len(widths) && l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+	table := util.NewTableStreamWriter(buf, "", header, widths...)
+	for i, r := range rows {
+		if this.changed && this.last != nil && !changed[i] {
+			continue
+		}
+		if changed[i] {
+			table.Row(highlight(style(r)))
+		} else {
+			table.Row(style(r))
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(buf, StyleError(fmt.Sprintf("Error: %s", err)))
+	} else {
+		this.last = current
+	}
+	os.Stdout.Write(buf.Bytes())
+}
@@ -20,11 +20,37 @@ const (
 	O_EXPORT   = "export"
 	O_DOWNLOAD = "download"
 
-	O_NOFILTER = "nofilter"
+	O_NOFILTER       = "nofilter"
+	O_ALL            = "all"
+	O_SELECTOR       = "selector"
+	O_FIELD_SELECTOR = "field-selector"
 
 	O_NODE = "node"
 	O_POD  = "pod"
+	O_REAP = "reap"
 
-	O_OUTPUT = "output"
-	O_SORT   = "sort"
+	O_CONTAINER = "container"
+	O_IMAGE     = "image"
+
+	O_PARALLEL           = "parallel"
+	O_PARALLEL_PERTARGET = "parallel-per-target"
+	O_TASK_TIMEOUT       = "task-timeout"
+
+	O_OUTPUT    = "output"
+	O_SORT      = "sort"
+	O_NOHEADERS = "no-headers"
+
+	O_WATCH          = "watch"
+	O_WATCH_INTERVAL = "watch-interval"
+	O_WATCH_CHANGED  = "changed-only"
+
+	O_SHOWSECRETS = "show-secrets"
+	O_NOCOLOR     = "no-color"
+	O_REFRESH     = "refresh"
+
+	O_SINCE  = "since"
+	O_ACTION = "action"
+	O_USER   = "user"
+
+	O_KIND = "kind"
 )
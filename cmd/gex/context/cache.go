@@ -12,18 +12,34 @@ func (this *Context) CacheDir() string {
 	return filepath.Join(this.Gexdir, "cache", this.Name)
 }
 
+// ElementCacheDir provides the directory for the persistent
+// element lists of a garden.
+func (this *Context) ElementCacheDir(garden string) string {
+	if this.Gexdir == "" || garden == "" {
+		return ""
+	}
+	return filepath.Join(this.Gexdir, "cache", garden, "elements")
+}
+
+func (this *Context) cacheDirForGarden(g gube.Garden) string {
+	if g == nil || g.GetName() == "" {
+		return this.CacheDir()
+	}
+	return filepath.Join(this.Gexdir, "cache", g.GetName())
+}
+
 func (this *Context) CacheDirForShoot(s gube.Shoot) string {
 	if this.Gexdir == "" {
 		return ""
 	}
-	return filepath.Join(this.CacheDir(), "projects", s.GetName().GetProjectName(), s.GetName().GetName())
+	return filepath.Join(this.cacheDirForGarden(s.Garden()), "projects", s.GetName().GetProjectName(), s.GetName().GetName())
 }
 
 func (this *Context) CacheDirForSeed(s gube.Seed) string {
 	if this.Gexdir == "" {
 		return ""
 	}
-	return filepath.Join(this.CacheDir(), "seeds", s.GetName())
+	return filepath.Join(this.cacheDirForGarden(s.Garden()), "seeds", s.GetName())
 }
 
 func (this *Context) CacheDirForGarden() string {
@@ -1,11 +1,21 @@
 package context
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/pkg"
+	"github.com/afritzler/garden-examiner/pkg/data"
 )
 
+const DEFAULT_PARALLELISM = 20
+const DEFAULT_TARGET_PARALLELISM = 5
+const DEFAULT_TASK_TIMEOUT = time.Minute
+
 type Context struct {
 	ByKubeconfig    bool
 	Configpath      string
@@ -14,6 +24,12 @@ type Context struct {
 	GardenSetConfig gube.GardenSetConfig
 	GardenConfig    gube.GardenConfig
 	Garden          gube.CachedGarden
+	Parallelism     gube.ParallelismConfig
+	TaskTimeout     time.Duration
+	Refresh         bool
+
+	lock    sync.Mutex
+	gardens map[string]gube.CachedGarden
 }
 
 func Get(opts *cmdint.Options) *Context {
@@ -38,3 +54,190 @@ func (this *Context) GetShoot(name *gube.ShootName) (gube.Shoot, error) {
 func (this *Context) GetShoots() (map[gube.ShootName]gube.Shoot, error) {
 	return this.Garden.GetShoots()
 }
+
+// GetGarden provides the cached garden for a garden name from the
+// garden set config. The empty name denotes the selected garden.
+func (this *Context) GetGarden(name string) (gube.CachedGarden, error) {
+	if name == "" || name == this.Name {
+		return this.Garden, nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if g, ok := this.gardens[name]; ok {
+		return g, nil
+	}
+	if this.GardenSetConfig == nil {
+		return nil, fmt.Errorf("garden '%s' not found", name)
+	}
+	cfg, err := this.GardenSetConfig.GetConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	g, err := cfg.GetGarden()
+	if err != nil {
+		return nil, err
+	}
+	if this.gardens == nil {
+		this.gardens = map[string]gube.CachedGarden{}
+	}
+	cg, err := this.NewCachedGarden(g)
+	if err != nil {
+		return nil, err
+	}
+	this.gardens[name] = cg
+	return cg, nil
+}
+
+// GetLiveGarden provides a garden for a garden name from the garden
+// set config reading the current elements from the garden, even if
+// a persistent element cache is configured. The empty name denotes
+// the selected garden.
+func (this *Context) GetLiveGarden(name string) (gube.CachedGarden, error) {
+	if name == "" {
+		name = this.Name
+	}
+	if this.GardenSetConfig == nil {
+		return nil, fmt.Errorf("garden '%s' not found", name)
+	}
+	cfg, err := this.GardenSetConfig.GetConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	g, err := cfg.GetGarden()
+	if err != nil {
+		return nil, err
+	}
+	return gube.NewCachedGarden(g), nil
+}
+
+// ResetGardens discards the cached elements of all gardens used so far.
+// The gardens and their connections are kept.
+func (this *Context) ResetGardens() {
+	if this.Garden != nil {
+		this.Garden.Reset()
+	}
+	for _, g := range this.gardens {
+		g.Reset()
+	}
+}
+
+// NewCachedGarden provides a cached garden for a garden. If configured
+// the element lists are kept persistently in the garden's cache dir.
+func (this *Context) NewCachedGarden(g gube.Garden) (gube.CachedGarden, error) {
+	var cfg *gube.CacheConfig
+	if this.GardenSetConfig != nil {
+		cfg = this.GardenSetConfig.GetCacheConfig()
+	}
+	dir := this.ElementCacheDir(g.GetName())
+	if cfg == nil || dir == "" {
+		return gube.NewCachedGarden(g), nil
+	}
+	return gube.NewPersistentCachedGarden(g, dir, cfg, this.Refresh)
+}
+
+// IsSelectedGarden checks whether the given garden name denotes the
+// selected garden.
+func (this *Context) IsSelectedGarden(name string) bool {
+	return name == "" || name == this.Name
+}
+
+// ResolveName resolves a potentially fully qualified element name
+// to the garden it belongs to and the garden local element name.
+func (this *Context) ResolveName(name string) (string, gube.CachedGarden, string, error) {
+	garden, local := gube.SplitQualifiedName(name)
+	g, err := this.GetGarden(garden)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if garden == "" {
+		garden = this.Name
+	}
+	return garden, g, local, nil
+}
+
+// GetShootByName provides a shoot for a potentially fully qualified
+// shoot name (<garden>:<project>/<shoot>).
+func (this *Context) GetShootByName(name string) (gube.Shoot, error) {
+	_, g, local, err := this.ResolveName(name)
+	if err != nil {
+		return nil, err
+	}
+	sn, err := gube.ParseShootName(local)
+	if err != nil {
+		return nil, err
+	}
+	return g.GetShoot(sn)
+}
+
+// GetSeedByName provides a seed for a potentially fully qualified
+// seed name (<garden>:<seed>).
+func (this *Context) GetSeedByName(name string) (gube.Seed, error) {
+	_, g, local, err := this.ResolveName(name)
+	if err != nil {
+		return nil, err
+	}
+	return g.GetSeed(local)
+}
+
+// DisplayName provides the name to display for an element name
+// of the given garden. Elements of other gardens than the selected
+// one are shown fully qualified.
+func (this *Context) DisplayName(g gube.Garden, name string) string {
+	if g == nil || this.IsSelectedGarden(g.GetName()) {
+		return name
+	}
+	return gube.QualifiedName(g.GetName(), name)
+}
+
+// IsQualified checks whether a name is a fully qualified element name.
+func IsQualified(name string) bool {
+	return strings.Contains(name, gube.GARDEN_SEPARATOR)
+}
+
+// GetShellConfig provides the completed node shell config for the
+// garden an element belongs to.
+func (this *Context) GetShellConfig(g gube.Garden) *gube.ShellConfig {
+	cfg := this.GardenConfig
+	if g != nil && !this.IsSelectedGarden(g.GetName()) && this.GardenSetConfig != nil {
+		if c, err := this.GardenSetConfig.GetConfig(g.GetName()); err == nil {
+			cfg = c
+		}
+	}
+	if cfg == nil {
+		return (*gube.ShellConfig)(nil).Complete()
+	}
+	return cfg.GetShellConfig()
+}
+
+// NewPool provides a processor pool obeying the configured parallelism.
+// Tasks are limited per key provided by the given key function, without
+// configured key limit DEFAULT_TARGET_PARALLELISM is used, a negative
+// key limit disables the limitation.
+// Configured keys are garden names or seed names, which are qualified
+// by the selected garden, if not given as <garden>:<seed>.
+func (this *Context) NewPool(key data.KeyFunction) data.KeyedProcessorPool {
+	limit := this.Parallelism.Limit
+	if limit <= 0 {
+		limit = DEFAULT_PARALLELISM
+	}
+	keylimit := this.Parallelism.KeyLimit
+	if keylimit == 0 {
+		keylimit = DEFAULT_TARGET_PARALLELISM
+	}
+	pool := data.NewKeyedProcessorPool(limit, keylimit, key)
+	for k, c := range this.Parallelism.Keys {
+		keys := []string{k}
+		if !strings.Contains(k, ":") {
+			keys = append(keys, this.Name+":"+k)
+		}
+		for _, k := range keys {
+			if c.Limit > 0 {
+				pool.SetKeyLimit(k, c.Limit)
+			}
+			if c.Priority != 0 {
+				pool.SetKeyPriority(k, c.Priority)
+			}
+		}
+	}
+	return pool
+}
@@ -0,0 +1,236 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/output"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+)
+
+func init() {
+	cmdint.MainTab().
+		SimpleCommand("diff", diff).
+		CmdDescription("compare gardens or snapshots",
+			"A source is a snapshot file (see snapshot command) or the name of",
+			"a garden of the gex config. With a single source it is compared to",
+			"the selected garden.",
+			"Added and removed elements, state and kubernetes version changes and",
+			"changed spec fields are reported for shoots, seeds and profiles.",
+			"Element kinds not contained in both sources are ignored.",
+		).
+		CmdArgDescription("<source> [<source>]").
+		ArgOption(constants.O_KIND).Short('k').ArgDescription("<kind>{,<kind>}").Description("element kinds (shoot,seed,profile)").
+		ArgOption(constants.O_OUTPUT).Short('o').ArgDescription("text|yaml|json|JSON")
+}
+
+func diff(opts *cmdint.Options) error {
+	ctx := context.Get(opts)
+	mode, err := output.DescribeMode(opts)
+	if err != nil {
+		return err
+	}
+	kinds, err := get_kinds(opts)
+	if err != nil {
+		return err
+	}
+	sources := opts.Arguments
+	switch len(sources) {
+	case 1:
+		sources = []string{sources[0], ""}
+	case 2:
+	default:
+		return fmt.Errorf("one or two sources required")
+	}
+	snapshots := make([]*Snapshot, 2)
+	names := make([]string, 2)
+	for i, src := range sources {
+		snapshots[i], names[i], err = get_source(ctx, src, kinds)
+		if err != nil {
+			return err
+		}
+	}
+
+	attrs := util.NewAttributeSet()
+	attrs.Attribute("From", names[0])
+	attrs.Attribute("To", names[1])
+	changes := 0
+	for _, k := range kinds {
+		if !snapshots[0].HasKind(k) || !snapshots[1].HasKind(k) {
+			continue
+		}
+		changes += compare(attrs, k, snapshots[0].GetElements(k), snapshots[1].GetElements(k))
+	}
+	if changes == 0 {
+		attrs.Attribute("Result", "no differences")
+	}
+	return output.PrintDescription(mode, attrs)
+}
+
+// get_source provides a snapshot for a snapshot file or a garden name,
+// the empty name denotes the selected garden. Gardens are always read
+// live, a persistent element cache is not used.
+func get_source(ctx *context.Context, src string, kinds []string) (*Snapshot, string, error) {
+	if fi, err := os.Stat(src); src != "" && err == nil && !fi.IsDir() {
+		s, err := LoadSnapshot(src)
+		if err != nil {
+			return nil, "", err
+		}
+		return s, fmt.Sprintf("snapshot %s (garden %s, %s)", src, s.Garden, s.Time.Local().Format(time.RFC3339)), nil
+	}
+	g, err := ctx.GetLiveGarden(src)
+	if err != nil {
+		return nil, "", err
+	}
+	if src == "" {
+		src = ctx.Name
+	}
+	s, err := TakeSnapshot(src, g, kinds)
+	if err != nil {
+		return nil, "", err
+	}
+	return s, fmt.Sprintf("garden %s", src), nil
+}
+
+// compare adds the differences of the elements of one kind as section
+// to the attribute set and provides the number of changed elements.
+func compare(attrs *util.AttributeSet, kind string, old, new map[string]*Element) int {
+	added := []string{}
+	removed := []string{}
+	states := [][]string{}
+	versions := [][]string{}
+	specs := [][]string{}
+
+	changed := map[string]bool{}
+	for _, name := range sorted_names(old, new) {
+		o, n := old[name], new[name]
+		switch {
+		case o == nil:
+			added = append(added, name)
+		case n == nil:
+			removed = append(removed, name)
+		default:
+			if o.State != n.State {
+				states = append(states, []string{name, none(o.State), none(n.State)})
+				changed[name] = true
+			}
+			if o.Version != n.Version {
+				versions = append(versions, []string{name, none(o.Version), none(n.Version)})
+				changed[name] = true
+			}
+			for _, f := range spec_diff(o.Spec, n.Spec) {
+				specs = append(specs, append([]string{name}, f...))
+				changed[name] = true
+			}
+		}
+	}
+
+	count := len(added) + len(removed) + len(changed)
+	if count == 0 {
+		return 0
+	}
+	section := attrs.Section(strings.Title(kind) + "s")
+	section.Attributef("Summary", "%d added, %d removed, %d changed", len(added), len(removed), len(changed))
+	if len(added) > 0 {
+		section.List("Added", added...)
+	}
+	if len(removed) > 0 {
+		section.List("Removed", removed...)
+	}
+	if len(states) > 0 {
+		section.Table("State Changes", []string{"NAME", "FROM", "TO"}, states)
+	}
+	if len(versions) > 0 {
+		section.Table("Version Changes", []string{"NAME", "FROM", "TO"}, versions)
+	}
+	if len(specs) > 0 {
+		section.Table("Spec Changes", []string{"NAME", "FIELD", "FROM", "TO"}, specs)
+	}
+	return count
+}
+
+func sorted_names(maps ...map[string]*Element) []string {
+	found := map[string]bool{}
+	names := []string{}
+	for _, m := range maps {
+		for n := range m {
+			if !found[n] {
+				found[n] = true
+				names = append(names, n)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func none(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// spec_diff provides the changed fields of two specs as
+// field, old and new value.
+func spec_diff(old, new interface{}) [][]string {
+	o := map[string]string{}
+	n := map[string]string{}
+	flatten("", old, o)
+	flatten("", new, n)
+	fields := []string{}
+	for f := range o {
+		fields = append(fields, f)
+	}
+	for f := range n {
+		if _, ok := o[f]; !ok {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	result := [][]string{}
+	for _, f := range fields {
+		ov, ook := o[f]
+		nv, nok := n[f]
+		if !ook {
+			ov = "<none>"
+		}
+		if !nok {
+			nv = "<none>"
+		}
+		if ov != nv {
+			result = append(result, []string{f, ov, nv})
+		}
+	}
+	return result
+}
+
+// flatten maps the leaf values of generic json data to their field path.
+// Empty maps and lists are omitted.
+func flatten(path string, data interface{}, fields map[string]string) {
+	switch d := data.(type) {
+	case map[string]interface{}:
+		for k, v := range d {
+			if path == "" {
+				flatten(k, v, fields)
+			} else {
+				flatten(path+"."+k, v, fields)
+			}
+		}
+	case []interface{}:
+		for i, v := range d {
+			flatten(fmt.Sprintf("%s[%d]", path, i), v, fields)
+		}
+	default:
+		if path != "" {
+			fields[path] = util.JSONValueString(data)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+const (
+	KIND_SHOOT   = "shoot"
+	KIND_SEED    = "seed"
+	KIND_PROFILE = "profile"
+)
+
+var KINDS = []string{KIND_SHOOT, KIND_SEED, KIND_PROFILE}
+
+// Element is the comparable state of a garden element. The kubernetes
+// version is kept separately and is not part of the spec.
+type Element struct {
+	Kind    string      `json:"kind"`
+	Name    string      `json:"name"`
+	State   string      `json:"state,omitempty"`
+	Version string      `json:"version,omitempty"`
+	Spec    interface{} `json:"spec,omitempty"`
+}
+
+// Snapshot is the state of the elements of a garden at some time.
+type Snapshot struct {
+	Garden   string     `json:"garden"`
+	Time     time.Time  `json:"time"`
+	Kinds    []string   `json:"kinds"`
+	Elements []*Element `json:"elements"`
+}
+
+// HasKind checks whether the snapshot contains the elements
+// of the given kind.
+func (this *Snapshot) HasKind(kind string) bool {
+	for _, k := range this.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// GetElements provides the elements of the given kind by name.
+func (this *Snapshot) GetElements(kind string) map[string]*Element {
+	result := map[string]*Element{}
+	for _, e := range this.Elements {
+		if e.Kind == kind {
+			result[e.Name] = e
+		}
+	}
+	return result
+}
+
+func init() {
+	cmdint.MainTab().
+		SimpleCommand("snapshot", snapshot).
+		CmdDescription("save a snapshot of a garden",
+			"The shoots, seeds and profiles of a garden are written as json",
+			"to the given file or to stdout. Snapshots can be compared to",
+			"gardens or other snapshots with the diff command.",
+			"The elements are always read from the garden, a persistent",
+			"element cache is not used.",
+		).
+		CmdArgDescription("[<file>]").
+		ArgOption(constants.O_GARDEN).Short('g').ArgDescription("<garden>").Description("garden to take snapshot of (default is selected garden)").
+		ArgOption(constants.O_KIND).Short('k').ArgDescription("<kind>{,<kind>}").Description("element kinds (shoot,seed,profile)")
+}
+
+func snapshot(opts *cmdint.Options) error {
+	if len(opts.Arguments) > 1 {
+		return fmt.Errorf("only one snapshot file possible")
+	}
+	ctx := context.Get(opts)
+	kinds, err := get_kinds(opts)
+	if err != nil {
+		return err
+	}
+	name := util.StringValue(opts.GetOptionValue(constants.O_GARDEN))
+	g, err := ctx.GetLiveGarden(name)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = ctx.Name
+	}
+	s, err := TakeSnapshot(name, g, kinds)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal snapshot: %s", err)
+	}
+	data = append(data, '\n')
+	if len(opts.Arguments) == 0 {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	err = ioutil.WriteFile(opts.Arguments[0], data, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot write snapshot '%s': %s", opts.Arguments[0], err)
+	}
+	return nil
+}
+
+func get_kinds(opts *cmdint.Options) ([]string, error) {
+	v := opts.GetOptionValue(constants.O_KIND)
+	if v == nil {
+		return KINDS, nil
+	}
+	kinds := []string{}
+	for _, k := range strings.Split(*v, ",") {
+		k = strings.TrimSuffix(strings.TrimSpace(k), "s")
+		switch k {
+		case KIND_SHOOT, KIND_SEED, KIND_PROFILE:
+			kinds = append(kinds, k)
+		default:
+			return nil, fmt.Errorf("invalid element kind '%s'", k)
+		}
+	}
+	return kinds, nil
+}
+
+// TakeSnapshot provides a snapshot of the given element kinds
+// of a garden.
+func TakeSnapshot(name string, g gube.Garden, kinds []string) (*Snapshot, error) {
+	s := &Snapshot{Garden: name, Time: time.Now().UTC(), Kinds: kinds, Elements: []*Element{}}
+	for _, k := range kinds {
+		var elems []*Element
+		var err error
+		switch k {
+		case KIND_SHOOT:
+			elems, err = shoot_elements(g)
+		case KIND_SEED:
+			elems, err = seed_elements(g)
+		case KIND_PROFILE:
+			elems, err = profile_elements(g)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot get %ss of garden '%s': %s", k, name, err)
+		}
+		sort.Slice(elems, func(i, j int) bool { return elems[i].Name < elems[j].Name })
+		s.Elements = append(s.Elements, elems...)
+	}
+	return s, nil
+}
+
+// LoadSnapshot reads a snapshot file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read snapshot '%s': %s", path, err)
+	}
+	s := &Snapshot{}
+	err = json.Unmarshal(data, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot '%s': %s", path, err)
+	}
+	return s, nil
+}
+
+func shoot_elements(g gube.Garden) ([]*Element, error) {
+	shoots, err := g.GetShoots()
+	if err != nil {
+		return nil, err
+	}
+	elems := []*Element{}
+	for _, s := range shoots {
+		m := s.GetManifest()
+		spec, err := util.ToJSONData(m.Spec)
+		if err != nil {
+			return nil, err
+		}
+		remove_field(spec, "kubernetes", "version")
+		elems = append(elems, &Element{
+			Kind:    KIND_SHOOT,
+			Name:    s.GetName().String(),
+			State:   s.GetState(),
+			Version: m.Spec.Kubernetes.Version,
+			Spec:    spec,
+		})
+	}
+	return elems, nil
+}
+
+func seed_elements(g gube.Garden) ([]*Element, error) {
+	seeds, err := g.GetSeeds()
+	if err != nil {
+		return nil, err
+	}
+	elems := []*Element{}
+	for _, s := range seeds {
+		spec, err := util.ToJSONData(s.GetManifest().Spec)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, &Element{
+			Kind:  KIND_SEED,
+			Name:  s.GetName(),
+			State: seed_state(s),
+			Spec:  spec,
+		})
+	}
+	return elems, nil
+}
+
+// seed_state describes the state of a seed by the status of its
+// conditions, e.g. Available=True.
+func seed_state(s gube.Seed) string {
+	states := []string{}
+	for _, c := range s.GetManifest().Status.Conditions {
+		states = append(states, fmt.Sprintf("%s=%s", c.Type, c.Status))
+	}
+	sort.Strings(states)
+	return strings.Join(states, ",")
+}
+
+// profile_elements uses the supported kubernetes versions of the
+// profiles as version.
+func profile_elements(g gube.Garden) ([]*Element, error) {
+	profiles, err := g.GetProfiles()
+	if err != nil {
+		return nil, err
+	}
+	elems := []*Element{}
+	for _, p := range profiles {
+		spec, err := util.ToJSONData(p.GetManifest().Spec)
+		if err != nil {
+			return nil, err
+		}
+		path := []string{p.GetInfrastructure(), "constraints", "kubernetes", "versions"}
+		versions := []string{}
+		if list, ok := remove_field(spec, path...).([]interface{}); ok {
+			for _, v := range list {
+				versions = append(versions, util.JSONValueString(v))
+			}
+		}
+		elems = append(elems, &Element{
+			Kind:    KIND_PROFILE,
+			Name:    p.GetName(),
+			Version: strings.Join(versions, ", "),
+			Spec:    spec,
+		})
+	}
+	return elems, nil
+}
+
+// remove_field removes a nested field from generic json data
+// and provides its former value.
+func remove_field(data interface{}, path ...string) interface{} {
+	for i, f := range path {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if i == len(path)-1 {
+			v := m[f]
+			delete(m, f)
+			return v
+		}
+		data = m[f]
+	}
+	return nil
+}
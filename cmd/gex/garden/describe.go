@@ -2,11 +2,11 @@ package garden
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
@@ -16,43 +16,55 @@ import (
 func init() {
 	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "describe", describe).CmdDescription(
 		"describe garden(s)",
+		"the description can be printed as text (default), yaml or json,",
+		"json always prints a list of descriptions",
 	).
-		CmdArgDescription("[<garden>]"))
+		CmdArgDescription("[<garden>]")).
+		ArgOption(constants.O_OUTPUT).Short('o').ArgDescription("text|yaml|json|JSON")
 }
 
 func describe(opts *cmdint.Options) error {
-	return cmdline.ExecuteOutput(opts, NewDescribeOutput(), TypeHandler)
+	mode, err := output.DescribeMode(opts)
+	if err != nil {
+		return err
+	}
+	return cmdline.ExecuteOutput(opts, NewDescribeOutput(mode), TypeHandler)
 }
 
 /////////////////////////////////////////////////////////////////////////////
 
 type describe_output struct {
 	*output.ElementOutput
+	mode string
 }
 
-func NewDescribeOutput() *describe_output {
-	o := &describe_output{}
+func NewDescribeOutput(mode string) *describe_output {
+	o := &describe_output{mode: mode}
 	o.ElementOutput = output.NewElementOutput(nil)
 	return o
 }
 
 func (this *describe_output) Out(ctx *context.Context) error {
+	printer := output.NewDescriptionPrinter(this.mode)
 	i := this.Elems.Iterator()
 	for i.HasNext() {
-		fmt.Printf("---\n")
 		out, err := NewOutput(i.Next().(gube.GardenConfig))
 		if err != nil {
 			return err
 		}
 		out.Describe()
+		if err := printer.Print(out.AttributeSet); err != nil {
+			return err
+		}
 	}
-	return nil
+	return printer.Close()
 }
 
 type Output struct {
 	config   gube.GardenConfig
 	garden   gube.Garden
 	infokube *util.InfoKube
+	overview map[string]map[string]map[string]int
 	*util.AttributeSet
 }
 
@@ -67,6 +79,7 @@ func NewOutput(cfg gube.GardenConfig) (*Output, error) {
 	o.garden = g
 	dimensions := []string{"Infra", "Seed", "Profile", "Region"}
 	o.infokube = util.NewInfoKube(dimensions)
+	o.overview = map[string]map[string]map[string]int{}
 	shoots, err := g.GetShoots()
 	if err != nil {
 		return nil, err
@@ -79,6 +92,7 @@ func NewOutput(cfg gube.GardenConfig) (*Output, error) {
 		}
 		o.infokube.AddElement(nil, s.GetInfrastructure(), sn,
 			s.GetProfileName(), s.GetRegion())
+		o.count(s.GetInfrastructure(), s.GetRegion(), s.GetSeedName())
 	}
 
 	seeds, err := g.GetSeeds()
@@ -92,13 +106,30 @@ func NewOutput(cfg gube.GardenConfig) (*Output, error) {
 	return o, nil
 }
 
+// count counts a shoot for the infrastructure overview
+// (infrastructure -> region -> seed -> number of shoots).
+func (this *Output) count(infra, region, seed string) {
+	regions := this.overview[infra]
+	if regions == nil {
+		regions = map[string]map[string]int{}
+		this.overview[infra] = regions
+	}
+	seeds := regions[region]
+	if seeds == nil {
+		seeds = map[string]int{}
+		regions[region] = seeds
+	}
+	seeds[seed]++
+}
+
 func (this *Output) Describe() error {
 	this.ResetAttributes()
-	this.Attributef("Garden", "%s (%s)", this.config.GetName(), this.config.GetDescription())
-	this.Attribute("Total Number of Shoots", strconv.Itoa(this.infokube.GetCount()))
-	this.Attribute("Total Number of Seeds", strconv.Itoa(len(this.infokube.GetKeys("Seed"))))
-	this.PrintAttributes()
-	fmt.Printf("Infrastructure Overview:\n")
-	this.infokube.Table("", []string{"Infra", "Region", "Seed"}, util.Coord{})
+	this.Attribute("Garden", this.config.GetName())
+	this.Attribute("Description", this.config.GetDescription())
+	this.AttributeValue("Total Number of Shoots", this.infokube.GetCount())
+	this.AttributeValue("Total Number of Seeds", len(this.infokube.GetKeys("Seed")))
+	this.Block("Infrastructure Overview", this.overview, func(gap string) {
+		this.infokube.Table(gap, []string{"Infra", "Region", "Seed"}, util.Coord{})
+	})
 	return nil
 }
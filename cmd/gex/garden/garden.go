@@ -7,6 +7,7 @@ import (
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
+	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
@@ -30,7 +31,7 @@ func GetCmdTab() cmdint.ConfigurableCmdTab {
 
 /////////////////////////////////////////////////////////////////////////////
 
-var filters *util.Filters = util.NewFilters()
+var filters *util.Filters = util.NewFilters().Add(&cmdline.SelectorFilter{NoLabels: true, NoManifest: true})
 
 /////////////////////////////////////////////////////////////////////////////
 
@@ -55,6 +56,10 @@ func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]
 	return a, nil
 }
 
+func (this *_TypeHandler) Reset() {
+	this.data = nil
+}
+
 func (this *_TypeHandler) GetFilter() util.Filter {
 	return filters
 }
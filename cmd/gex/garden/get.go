@@ -7,15 +7,17 @@ import (
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/pkg"
 	"github.com/afritzler/garden-examiner/pkg/data"
 )
 
 func init() {
-	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).CmdDescription("get configured garden(s)").
-		CmdArgDescription("[<garden>]").Mixed()).
-		ArgOption(constants.O_OUTPUT).Short('o')
+	filters.AddOptions(cmdline.AddWatchOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).CmdDescription("get configured garden(s)").
+		CmdArgDescription("[<garden>]").Mixed())).
+		ArgOption(constants.O_OUTPUT).Short('o').
+		FlagOption(constants.O_NOHEADERS).Description("omit header in csv and tsv output")
 }
 
 func get(opts *cmdint.Options) error {
@@ -34,8 +36,10 @@ func get_regular(opts *cmdint.Options) output.Output {
 }
 
 func get_wide(opts *cmdint.Options) output.Output {
-	return output.NewProcessingTableOutput(opts, data.Chain().Parallel(20).Map(map_get_wide_output),
-		"GARDEN", "HOST", "-PROJECTS", "-SHOOTS", "-SEEDS", "DESCRIPTION")
+	ctx := context.Get(opts)
+	return output.NewProcessingTableOutput(opts, data.Chain().WithPool(ctx.NewPool(output.TargetKey)).
+		WithTimeout(ctx.TaskTimeout, map_get_wide_timeout).Map(map_get_wide_output),
+		"GARDEN", "HOST", "-PROJECTS:number", "-SHOOTS:number", "-SEEDS:number", "DESCRIPTION")
 }
 
 func map_get_regular_output(e interface{}) interface{} {
@@ -51,6 +55,11 @@ func map_get_regular_output(e interface{}) interface{} {
 	return []string{p.GetName(), host, p.GetDescription()}
 }
 
+func map_get_wide_timeout(e interface{}) interface{} {
+	c := e.(gube.GardenConfig)
+	return []string{c.GetName(), "timeout", "?", "?", "?", c.GetDescription()}
+}
+
 func map_get_wide_output(e interface{}) interface{} {
 	c := e.(gube.GardenConfig)
 	projects := "unknown"
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"os/user"
+	"strconv"
+	"time"
 
+	_ "github.com/afritzler/garden-examiner/cmd/gex/cache"
+	_ "github.com/afritzler/garden-examiner/cmd/gex/diff"
 	_ "github.com/afritzler/garden-examiner/cmd/gex/profile"
 	_ "github.com/afritzler/garden-examiner/cmd/gex/project"
 	_ "github.com/afritzler/garden-examiner/cmd/gex/seed"
@@ -13,8 +17,11 @@ import (
 	_ "github.com/afritzler/garden-examiner/cmd/gex/shoot"
 	_ "github.com/afritzler/garden-examiner/cmd/gex/verb"
 
+	"github.com/afritzler/garden-examiner/cmd/gex/audit"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/output"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
 	"github.com/afritzler/garden-examiner/pkg/data"
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
@@ -36,16 +43,33 @@ func main() {
 		ArgOption(constants.O_SEL_SHOOT).Env("GEX_SHOOT").
 		ArgOption(constants.O_SEL_PROJECT).Env("GEX_PROJECT").
 		ArgOption(constants.O_SEL_SEED).Env("GEX_SEED").
-		ArgOption(constants.O_SEL_GARDEN).Env("GEX_GARDEN")
+		ArgOption(constants.O_SEL_GARDEN).Env("GEX_GARDEN").
+		FlagOption(constants.O_SHOWSECRETS).Description("show credentials and sensitive values").
+		FlagOption(constants.O_NOCOLOR).Description("disable colored output (also by env NO_COLOR)").
+		FlagOption(constants.O_REFRESH).Description("ignore persistently cached garden content").
+		ArgOption(constants.O_PARALLEL).ArgDescription("<n>").Description("maximum number of parallel requests").
+		ArgOption(constants.O_PARALLEL_PERTARGET).ArgDescription("<n>").Description("maximum number of parallel requests per seed or garden (default 5, -1 for unlimited)").
+		ArgOption(constants.O_TASK_TIMEOUT).ArgDescription("<duration>").Description("timeout for a single parallel request (0 for none)")
 
-	cmdint.Run()
+	err = cmdint.MainTab().Execute(nil, os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		// pass the exit code of a failed executed command
+		if e := util.LastExitError(); e != nil {
+			os.Exit(e.Code)
+		}
+		os.Exit(1)
+	}
 }
 
 func setup(opts *cmdint.Options) error {
 	c := &context.Context{}
 	opts.Context = c
 
+	output.ShowSecrets(opts.IsFlag(constants.O_SHOWSECRETS))
+	output.EnableColors(!opts.IsFlag(constants.O_NOCOLOR))
 	c.Gexdir = *opts.GetOptionValue(constants.O_GEXDIR)
+	c.Refresh = opts.IsFlag(constants.O_REFRESH)
 	gexconfig := opts.GetOptionValue(constants.O_GEXCONFIG)
 	if data.IsEmpty(gexconfig) && !data.IsEmpty(c.Gexdir) {
 		cfg := filepath.Join(c.Gexdir, "config")
@@ -76,7 +100,10 @@ func setup(opts *cmdint.Options) error {
 		if err != nil {
 			return err
 		}
-		c.Garden = gube.NewCachedGarden(g)
+		c.Garden, err = c.NewCachedGarden(g)
+		if err != nil {
+			return err
+		}
 	} else {
 		configfile := opts.GetOptionValue(constants.O_KUBECONFIG)
 		if data.IsEmpty(configfile) {
@@ -90,13 +117,54 @@ func setup(opts *cmdint.Options) error {
 		//}
 
 		//g, err := gube.NewGarden(config)
-		g, err := gube.NewGardenFromConfigfile(*configfile)
+		g, err := gube.NewNamedGardenFromConfigfile("default", *configfile)
 		if err != nil {
 			return err
 		}
 		c.GardenSetConfig = gube.NewDefaultGardenSetConfig(g)
-		c.Garden = gube.NewCachedGarden(g)
+		c.Garden, err = c.NewCachedGarden(g)
+		if err != nil {
+			return err
+		}
 		c.Name = "default"
 	}
+	if p := c.GardenSetConfig.GetParallelism(); p != nil {
+		c.Parallelism = *p
+	}
+	if err := intOption(opts, constants.O_PARALLEL, 0, &c.Parallelism.Limit); err != nil {
+		return err
+	}
+	if err := intOption(opts, constants.O_PARALLEL_PERTARGET, -1, &c.Parallelism.KeyLimit); err != nil {
+		return err
+	}
+	c.TaskTimeout = context.DEFAULT_TASK_TIMEOUT
+	if c.Parallelism.Timeout != "" {
+		d, err := time.ParseDuration(c.Parallelism.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid parallelism timeout '%s': %s", c.Parallelism.Timeout, err)
+		}
+		c.TaskTimeout = d
+	}
+	if v := opts.GetOptionValue(constants.O_TASK_TIMEOUT); !data.IsEmpty(v) {
+		d, err := time.ParseDuration(*v)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for option --%s", *v, constants.O_TASK_TIMEOUT)
+		}
+		c.TaskTimeout = d
+	}
+	audit.Setup(c)
+	return nil
+}
+
+func intOption(opts *cmdint.Options, name string, min int, value *int) error {
+	v := opts.GetOptionValue(name)
+	if data.IsEmpty(v) {
+		return nil
+	}
+	n, err := strconv.Atoi(*v)
+	if err != nil || n < min {
+		return fmt.Errorf("invalid value '%s' for option --%s", *v, name)
+	}
+	*value = n
 	return nil
 }
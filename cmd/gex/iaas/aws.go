@@ -26,12 +26,27 @@ func (this *aws) Execute(shoot gube.Shoot, config map[string]string, args ...str
 }
 
 func (this *aws) Export(shoot gube.Shoot, config map[string]string, cachedir string) error {
-	region := shoot.GetRegion()
+	dir, err := ConfigDir(cachedir, "aws")
+	if err != nil {
+		return err
+	}
+	profile := ProfileName(shoot)
+	credentials := fmt.Sprintf("[%s]\naws_access_key_id = %s\naws_secret_access_key = %s\n",
+		profile, config["accessKeyID"], config["secretAccessKey"])
+	credfile, err := WriteConfigFile(dir, "credentials", []byte(credentials))
+	if err != nil {
+		return err
+	}
+	cfg := fmt.Sprintf("[profile %s]\nregion = %s\noutput = json\n", profile, shoot.GetRegion())
+	cfgfile, err := WriteConfigFile(dir, "config", []byte(cfg))
+	if err != nil {
+		return err
+	}
 	fmt.Printf("exporting AWS CLI config for %s\n", shoot.GetName())
-	env.Set("AWS_ACCESS_KEY_ID", string(config["accessKeyID"]))
-	env.Set("AWS_SECRET_ACCESS_KEY", string(config["secretAccessKey"]))
-	env.Set("AWS_DEFAULT_REGION", region)
-	env.Set("AWS_DEFAULT_OUTPUT", "json")
+	env.Set("AWS_SHARED_CREDENTIALS_FILE", credfile)
+	env.Set("AWS_CONFIG_FILE", cfgfile)
+	fmt.Printf("credentials written to %s\n", credfile)
+	fmt.Printf("use: AWS_SHARED_CREDENTIALS_FILE=%s AWS_CONFIG_FILE=%s aws --profile %s ...\n", credfile, cfgfile, profile)
 	return nil
 }
 
@@ -39,7 +54,7 @@ func (this *aws) Describe(shoot gube.Shoot, attrs *util.AttributeSet) error {
 	info, err := shoot.GetIaaSInfo()
 	if err == nil {
 		iaas := info.(*gube.AWSInfo)
-		attrs.Attribute("AWS Information", "")
+		attrs = attrs.Section("AWS Information")
 		attrs.Attribute("Region", iaas.GetRegion())
 		attrs.Attribute("VPC Id", iaas.GetVpcId())
 		attrs.Attribute("Security Group", iaas.GetNodesSecurityGroupId())
@@ -1,9 +1,11 @@
 package iaas
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/afritzler/garden-examiner/cmd/gex/env"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
 )
@@ -28,14 +30,41 @@ func (this *azure) Execute(shoot gube.Shoot, config map[string]string, args ...s
 }
 
 func (this *azure) Export(shoot gube.Shoot, config map[string]string, cachedir string) error {
-	return fmt.Errorf("no possible for Azure")
+	dir, err := ConfigDir(cachedir, "azure")
+	if err != nil {
+		return err
+	}
+	auth := map[string]string{
+		"clientId":                       config["clientID"],
+		"clientSecret":                   config["clientSecret"],
+		"subscriptionId":                 config["subscriptionID"],
+		"tenantId":                       config["tenantID"],
+		"activeDirectoryEndpointUrl":     "https://login.microsoftonline.com",
+		"resourceManagerEndpointUrl":     "https://management.azure.com/",
+		"activeDirectoryGraphResourceId": "https://graph.windows.net/",
+		"managementEndpointUrl":          "https://management.core.windows.net/",
+	}
+	data, err := json.MarshalIndent(auth, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal auth file: %s", err)
+	}
+	file, err := WriteConfigFile(dir, "auth.json", data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("exporting Azure auth file for %s\n", shoot.GetName())
+	env.Set("AZURE_AUTH_LOCATION", file)
+	fmt.Printf("credentials written to %s\n", file)
+	fmt.Printf("use: AZURE_AUTH_LOCATION=%s ...\n", file)
+	return nil
 }
 
 func (this *azure) Describe(shoot gube.Shoot, attrs *util.AttributeSet) error {
 	info, err := shoot.GetIaaSInfo()
 	if err == nil {
 		iaas := info.(*gube.AzureInfo)
-		attrs.Attribute("Azure Information", "")
+		attrs = attrs.Section("Azure Information")
 		attrs.Attribute("Region", iaas.GetRegion())
 		attrs.Attribute("Resource Group", iaas.GetResourceGroupName())
 		attrs.Attribute("VNet", iaas.GetVNetName())
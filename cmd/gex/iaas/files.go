@@ -0,0 +1,48 @@
+package iaas
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/filepath/pkg/filepath"
+
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+// ProfileName provides the name used for the CLI profile/cloud entries
+// generated for a shoot.
+func ProfileName(shoot gube.Shoot) string {
+	return "gex-" + strings.Replace(shoot.GetName().String(), "/", "-", -1)
+}
+
+// ConfigDir provides the (created) private directory used to store
+// CLI configuration files for an IaaS type below the cache dir of a shoot.
+func ConfigDir(cachedir string, iaas string) (string, error) {
+	if cachedir == "" {
+		return "", fmt.Errorf("no GEXDIR set")
+	}
+	dir := filepath.Join(cachedir, iaas)
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", fmt.Errorf("cannot create config dir '%s': %s", dir, err)
+	}
+	return dir, nil
+}
+
+// WriteConfigFile writes a private CLI configuration file.
+func WriteConfigFile(dir string, name string, data []byte) (string, error) {
+	path := filepath.Join(dir, name)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("cannot create config file '%s': %s", path, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return "", fmt.Errorf("cannot write config file '%s': %s", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("cannot close config file '%s': %s", path, err)
+	}
+	return path, nil
+}
@@ -3,14 +3,13 @@ package iaas
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	. "github.com/afritzler/garden-examiner/cmd/gex/cleanup"
+	"github.com/afritzler/garden-examiner/cmd/gex/env"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
 	"github.com/jmoiron/jsonq"
-	"github.com/mandelsoft/filepath/pkg/filepath"
 )
 
 func init() {
@@ -68,28 +67,38 @@ func (this *gcp) Execute(shoot gube.Shoot, config map[string]string, args ...str
 
 func (this *gcp) Export(shoot gube.Shoot, config map[string]string, cachedir string) error {
 	serviceaccount := []byte(config["serviceaccount.json"])
-	err := os.MkdirAll(cachedir, 0700)
+	data := map[string]interface{}{}
+	err := json.Unmarshal(serviceaccount, &data)
 	if err != nil {
-		return fmt.Errorf("cannot create cache dir '%s' for key file: %s", cachedir, err)
+		return fmt.Errorf("cannot parse service account: %s", err)
 	}
-	keyfile := filepath.Join(cachedir, "gcp.serviceaccount")
-
-	file, err := os.OpenFile(keyfile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0700)
+	project, err := jsonq.NewQuery(data).String("project_id")
 	if err != nil {
-		return fmt.Errorf("cannot create key file '%s' for key file: %s", keyfile, err)
+		return fmt.Errorf("cannot find project id in service account: %s", err)
 	}
-	if _, err := file.Write(serviceaccount); err != nil {
-		return fmt.Errorf("cannot write key file '%s' for key file: %s", keyfile, err)
+
+	dir, err := ConfigDir(cachedir, "gcloud")
+	if err != nil {
+		return err
 	}
-	if err := file.Close(); err != nil {
-		return fmt.Errorf("cannot close key file '%s' for key file: %s", keyfile, err)
+	keyfile, err := WriteConfigFile(cachedir, "gcp.serviceaccount", serviceaccount)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("activating gcloud service account for %s\n", shoot.GetName())
-	err = util.ExecCmd("gcloud auth activate-service-account --key-file="+keyfile, nil)
+	// use a dedicated gcloud configuration directory to keep the
+	// global gcloud configuration of the user untouched
+	fmt.Printf("activating gcloud service account for %s in %s\n", shoot.GetName(), dir)
+	err = util.ExecCmd("gcloud auth activate-service-account --key-file="+keyfile, nil, "CLOUDSDK_CONFIG="+dir)
 	if err != nil {
 		return fmt.Errorf("cannot activate service account: %s", err)
 	}
+	err = util.ExecCmd("gcloud config set project "+project, nil, "CLOUDSDK_CONFIG="+dir)
+	if err != nil {
+		return fmt.Errorf("cannot set gcloud project: %s", err)
+	}
+	env.Set("CLOUDSDK_CONFIG", dir)
+	fmt.Printf("use: CLOUDSDK_CONFIG=%s gcloud --project %s ...\n", dir, project)
 	return nil
 }
 
@@ -97,7 +106,7 @@ func (this *gcp) Describe(shoot gube.Shoot, attrs *util.AttributeSet) error {
 	info, err := shoot.GetIaaSInfo()
 	if err == nil {
 		iaas := info.(*gube.GCPInfo)
-		attrs.Attribute("GCP Information", "")
+		attrs = attrs.Section("GCP Information")
 		attrs.Attribute("Region", iaas.GetRegion())
 		attrs.Attribute("VPC Name", iaas.GetVpcName())
 		attrs.Attribute("Service Accout EMail", iaas.GetServiceAccountEMail())
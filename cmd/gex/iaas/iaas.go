@@ -1,8 +1,6 @@
 package iaas
 
 import (
-	"fmt"
-
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 
 	"github.com/afritzler/garden-examiner/pkg"
@@ -29,6 +27,6 @@ func Describe(shoot gube.Shoot, attrs *util.AttributeSet) error {
 	if h != nil {
 		return h.Describe(shoot, attrs)
 	}
-	fmt.Printf("no handler for infrastructure '%s'\n", shoot.GetInfrastructure())
+	attrs.Attributef("Infrastructure Information", "no handler for infrastructure '%s'", shoot.GetInfrastructure())
 	return nil
 }
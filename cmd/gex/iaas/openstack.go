@@ -7,6 +7,7 @@ import (
 	"github.com/afritzler/garden-examiner/cmd/gex/env"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
+	"github.com/ghodss/yaml"
 )
 
 func init() {
@@ -44,17 +45,38 @@ func (this *openstack) Export(shoot gube.Shoot, config map[string]string, cached
 		fmt.Println("Fetching authURL was not successful")
 		return nil
 	}
+	dir, err := ConfigDir(cachedir, "openstack")
+	if err != nil {
+		return err
+	}
+	cloud := ProfileName(shoot)
+	clouds := map[string]interface{}{
+		"clouds": map[string]interface{}{
+			cloud: map[string]interface{}{
+				"auth": map[string]string{
+					"auth_url":            authURL,
+					"project_name":        config["tenantName"],
+					"project_domain_name": config["domainName"],
+					"user_domain_name":    config["domainName"],
+					"username":            config["username"],
+					"password":            config["password"],
+				},
+				"region_name":          config["region"],
+				"identity_api_version": "3",
+			},
+		},
+	}
+	data, err := yaml.Marshal(clouds)
+	if err != nil {
+		return fmt.Errorf("cannot marshal clouds.yaml: %s", err)
+	}
+	file, err := WriteConfigFile(dir, "clouds.yaml", data)
+	if err != nil {
+		return err
+	}
 	fmt.Printf("exporting Openstack CLI config for %s\n", shoot.GetName())
-	env.Set("OS_IDENTITY_API_VERSION", "3")
-	env.Set("OS_AUTH_VERSION", "3")
-	env.Set("OS_AUTH_STRATEGY", "keystone")
-	env.Set("OS_AUTH_URL", authURL)
-	env.Set("OS_TENANT_NAME", string(config["tenantName"]))
-	env.Set("OS_PROJECT_DOMAIN_NAME", string(config["domainName"]))
-	env.Set("OS_USER_DOMAIN_NAME", string(config["domainName"]))
-	env.Set("OS_USERNAME", string(config["username"]))
-	env.Set("OS_PASSWORD,", string(config["password"]))
-	env.Set("OS_REGION_NAME", string(config["region"]))
+	env.Set("OS_CLIENT_CONFIG_FILE", file)
+	fmt.Printf("use: OS_CLIENT_CONFIG_FILE=%s openstack --os-cloud %s ...\n", file, cloud)
 	return nil
 }
 
@@ -62,12 +84,12 @@ func (this *openstack) Describe(shoot gube.Shoot, attrs *util.AttributeSet) erro
 	info, err := shoot.GetIaaSInfo()
 	if err == nil {
 		iaas := info.(*gube.OpenstackInfo)
-		attrs.Attribute("Openstack Information", "")
+		attrs = attrs.Section("Openstack Information")
 		attrs.Attribute("Keystone URL", iaas.GetAuthURL())
 		attrs.Attribute("Domain Name", iaas.GetDomainName())
 		attrs.Attribute("Tenant Name", iaas.GetTenantName())
 		attrs.Attribute("Username", iaas.GetUserName())
-		attrs.Attribute("Password", iaas.GetPassword())
+		attrs.SecretAttribute("Password", iaas.GetPassword())
 		attrs.Attribute("Region", iaas.GetRegion())
 		attrs.Attribute("Router Id", iaas.GetRouterId())
 		attrs.Attribute("Network Id", iaas.GetNetworkId())
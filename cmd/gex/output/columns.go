@@ -0,0 +1,222 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	. "github.com/afritzler/garden-examiner/pkg/data"
+)
+
+// Column types can be added to table header fields with the suffix
+// :<type>, e.g. "-NODES:number". They are used to sort the column.
+const (
+	COL_STRING  = "string"
+	COL_NUMBER  = "number"
+	COL_TIME    = "time"
+	COL_VERSION = "version"
+	COL_STATE   = "state"
+)
+
+// state_severity orders element states by severity.
+var state_severity = map[string]int{
+	"succeeded":  0,
+	"ready":      0,
+	"processing": 1,
+	"pending":    1,
+	"unknown":    2,
+	"aborted":    2,
+	"problem":    3,
+	"timeout":    3,
+	"error":      4,
+	"failed":     5,
+}
+
+type column struct {
+	name  string
+	ctype string
+}
+
+// parse_header splits the header fields into the header to print
+// and the column descriptions.
+func parse_header(header []string) ([]string, []column) {
+	fields := make([]string, len(header))
+	cols := make([]column, len(header))
+	for i, h := range header {
+		ctype := COL_STRING
+		if idx := strings.LastIndex(h, ":"); idx >= 0 {
+			ctype = h[idx+1:]
+			h = h[:idx]
+		}
+		fields[i] = h
+		cols[i] = column{strings.ToLower(strings.TrimPrefix(h, "-")), ctype}
+	}
+	return fields, cols
+}
+
+// sort_comparator provides a composite comparator for sort keys of the
+// form [+|-]<column>. A leading - sorts descending. Keys may be given
+// as comma separated list.
+func sort_comparator(cols []column, keys []string) (CompareFunction, error) {
+	names := make([]string, len(cols))
+	idxs := map[string]int{}
+	for i, c := range cols {
+		names[i] = c.name
+		idxs[c.name] = i
+	}
+	cmps := []CompareFunction{}
+	for _, list := range keys {
+		for _, k := range strings.Split(list, ",") {
+			k = strings.ToLower(strings.TrimSpace(k))
+			desc := false
+			switch {
+			case strings.HasPrefix(k, "-"):
+				desc = true
+				k = k[1:]
+			case strings.HasPrefix(k, "+"):
+				k = k[1:]
+			}
+			if k == "" {
+				continue
+			}
+			key, _ := cmdint.SelectBest(k, names...)
+			if key == "" {
+				return nil, fmt.Errorf("unknown field '%s'", k)
+			}
+			cmp := compare_column(idxs[key], compare_typed(cols[idxs[key]].ctype))
+			if desc {
+				cmp = descending(cmp)
+			}
+			cmps = append(cmps, cmp)
+		}
+	}
+	if len(cmps) == 0 {
+		return nil, nil
+	}
+	return func(a interface{}, b interface{}) int {
+		for _, c := range cmps {
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}, nil
+}
+
+func descending(c CompareFunction) CompareFunction {
+	return func(a interface{}, b interface{}) int {
+		return -c(a, b)
+	}
+}
+
+func compare_column(c int, cmp func(a, b string) int) CompareFunction {
+	return func(a interface{}, b interface{}) int {
+		aa := a.([]string)
+		ab := b.([]string)
+		if len(aa) > c && len(ab) > c {
+			return cmp(aa[c], ab[c])
+		}
+		return len(aa) - len(ab)
+	}
+}
+
+func compare_typed(ctype string) func(a, b string) int {
+	switch ctype {
+	case COL_NUMBER:
+		return compare_parsed(parse_number)
+	case COL_TIME:
+		return compare_parsed(parse_time)
+	case COL_VERSION:
+		return compare_version
+	case COL_STATE:
+		return compare_state
+	}
+	return strings.Compare
+}
+
+// compare_parsed compares values using a parse function. Values which
+// cannot be parsed are ordered after the parsable ones.
+func compare_parsed(parse func(string) (float64, bool)) func(a, b string) int {
+	return func(a, b string) int {
+		va, oka := parse(a)
+		vb, okb := parse(b)
+		switch {
+		case oka && okb:
+			if va < vb {
+				return -1
+			}
+			if va > vb {
+				return 1
+			}
+			return 0
+		case oka:
+			return -1
+		case okb:
+			return 1
+		}
+		return strings.Compare(a, b)
+	}
+}
+
+func parse_number(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return v, err == nil
+}
+
+// parse_time accepts time stamps and durations (ages).
+func parse_time(s string) (float64, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return float64(t.UnixNano()), true
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return float64(time.Now().Add(-d).UnixNano()), true
+	}
+	return 0, false
+}
+
+func compare_version(a, b string) int {
+	pa := version_parts(a)
+	pb := version_parts(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, erra := strconv.Atoi(pa[i])
+		nb, errb := strconv.Atoi(pb[i])
+		var r int
+		switch {
+		case erra == nil && errb == nil:
+			r = na - nb
+		case erra == nil:
+			r = 1
+		case errb == nil:
+			r = -1
+		default:
+			r = strings.Compare(pa[i], pb[i])
+		}
+		if r != 0 {
+			return r
+		}
+	}
+	return len(pa) - len(pb)
+}
+
+func version_parts(v string) []string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	return strings.FieldsFunc(v, func(r rune) bool { return r == '.' || r == '-' || r == '+' })
+}
+
+func compare_state(a, b string) int {
+	sa, oka := state_severity[strings.ToLower(a)]
+	sb, okb := state_severity[strings.ToLower(b)]
+	if !oka {
+		sa = state_severity["unknown"]
+	}
+	if !okb {
+		sb = state_severity["unknown"]
+	}
+	if sa != sb {
+		return sa - sb
+	}
+	return strings.Compare(a, b)
+}
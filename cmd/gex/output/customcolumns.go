@@ -0,0 +1,150 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg/data"
+)
+
+const CUSTOM_COLUMNS = "custom-columns"
+
+type column_spec struct {
+	header string
+	path   *util.JSONPath
+}
+
+// parse_custom_columns parses a column spec of the form
+// <header>[:<type>]:<jsonpath>{,<header>[:<type>]:<jsonpath>}.
+// A spec without colon is the name of a column set configured
+// in the gex config.
+func parse_custom_columns(ctx *context.Context, spec string) ([]column_spec, error) {
+	entries := split_columns(spec)
+	if len(entries) == 1 && !strings.Contains(spec, ":") {
+		var sets map[string][]string
+		if ctx.GardenSetConfig != nil {
+			sets = ctx.GardenSetConfig.GetColumnSets()
+		}
+		set, ok := sets[spec]
+		if !ok {
+			return nil, fmt.Errorf("unknown column set '%s'", spec)
+		}
+		entries = set
+	}
+	cols := []column_spec{}
+	for _, e := range entries {
+		fields := strings.SplitN(e, ":", 3)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid column spec '%s': <header>[:<type>]:<jsonpath> expected", e)
+		}
+		header, expr := fields[0], strings.Join(fields[1:], ":")
+		if len(fields) == 3 && is_column_type(fields[1]) {
+			header, expr = fields[0]+":"+fields[1], fields[2]
+		}
+		path, err := util.ParseJSONPath(expr)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, column_spec{header, path})
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns specified")
+	}
+	return cols, nil
+}
+
+// split_columns splits a column spec at commas outside of brackets
+// and quotes, which might be used in filter expressions.
+func split_columns(spec string) []string {
+	result := []string{}
+	level := 0
+	start := 0
+	var quote rune
+	for i, c := range spec {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '(' || c == '{':
+			level++
+		case c == ']' || c == ')' || c == '}':
+			level--
+		case c == ',':
+			if level == 0 {
+				result = append(result, strings.TrimSpace(spec[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if s := strings.TrimSpace(spec[start:]); s != "" {
+		result = append(result, s)
+	}
+	return result
+}
+
+func is_column_type(t string) bool {
+	switch t {
+	case COL_STRING, COL_NUMBER, COL_TIME, COL_VERSION, COL_STATE:
+		return true
+	}
+	return false
+}
+
+// CustomColumnsOutputFactory provides a table output with columns
+// given by JSONPath expressions evaluated on the element data
+// (see ElementData).
+func CustomColumnsOutputFactory(opts *cmdint.Options) Output {
+	ctx := context.Get(opts)
+	cols, err := parse_custom_columns(ctx, OutputArgument(opts))
+	if err != nil {
+		return NewErrorOutput(err)
+	}
+	header := make([]string, len(cols))
+	exprs := make([]string, len(cols))
+	for i, c := range cols {
+		header[i] = c.header
+		exprs[i] = c.path.String()
+	}
+	selected, all := referenced_attributes(exprs...)
+	if all {
+		selected = nil
+	}
+	mapper := func(e interface{}) (interface{}, error) {
+		d, err := ElementData(e, selected)
+		if err != nil {
+			return nil, err
+		}
+		row := make([]string, len(cols))
+		for i, c := range cols {
+			values, err := c.path.Eval(d)
+			if err != nil {
+				return nil, err
+			}
+			cells := make([]string, len(values))
+			for j, v := range values {
+				cells[j] = util.JSONValueString(v)
+			}
+			row[i] = strings.Join(cells, ",")
+			if len(values) == 0 {
+				row[i] = "<none>"
+			}
+		}
+		return row, nil
+	}
+	timeout := func(e interface{}) interface{} {
+		row := make([]string, len(cols))
+		for i := range row {
+			row[i] = "<timeout>"
+		}
+		return row
+	}
+	return NewProcessingTableOutput(opts, data.Chain().WithPool(ctx.NewPool(TargetKey)).
+		WithTimeout(ctx.TaskTimeout, timeout).MapWithError(mapper), header...)
+}
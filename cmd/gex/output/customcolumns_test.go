@@ -0,0 +1,67 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+)
+
+func TestSplitColumns(t *testing.T) {
+	tests := []struct {
+		spec    string
+		columns []string
+	}{
+		{"NAME:.metadata.name", []string{"NAME:.metadata.name"}},
+		{"NAME:.metadata.name, SEED:.spec.cloud.seed", []string{"NAME:.metadata.name", "SEED:.spec.cloud.seed"}},
+		{`A:.items[?(@.name=="a,b")].value,B:.b`, []string{`A:.items[?(@.name=="a,b")].value`, "B:.b"}},
+		{`A:.items[?(@.name=="a]b,c")].value,B:.b`, []string{`A:.items[?(@.name=="a]b,c")].value`, "B:.b"}},
+		{`A:.items[?(@.name=='x)')].value,B:.b`, []string{`A:.items[?(@.name=='x)')].value`, "B:.b"}},
+		{"A:{.a},B:{.b},", []string{"A:{.a}", "B:{.b}"}},
+	}
+	for _, test := range tests {
+		columns := split_columns(test.spec)
+		if !reflect.DeepEqual(columns, test.columns) {
+			t.Errorf("%s: expected %q, found %q", test.spec, test.columns, columns)
+		}
+	}
+}
+
+func TestParseCustomColumns(t *testing.T) {
+	tests := []struct {
+		spec   string
+		header []string
+		paths  []string
+		err    bool
+	}{
+		{"NAME:.metadata.name", []string{"NAME"}, []string{".metadata.name"}, false},
+		{"NODES:number:.spec.workers[0].max", []string{"NODES:number"}, []string{".spec.workers[0].max"}, false},
+		{`A:.items[?(@.v=="x:y,z")].n,B:.b`, []string{"A", "B"}, []string{`.items[?(@.v=="x:y,z")].n`, ".b"}, false},
+		{`A:.items[?(@.v<"a==b")].n`, []string{"A"}, []string{`.items[?(@.v<"a==b")].n`}, false},
+		{"unknown", nil, nil, true},
+		{"A:.items[", nil, nil, true},
+	}
+	ctx := &context.Context{}
+	for _, test := range tests {
+		cols, err := parse_custom_columns(ctx, test.spec)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: error expected", test.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.spec, err)
+			continue
+		}
+		header := []string{}
+		paths := []string{}
+		for _, c := range cols {
+			header = append(header, c.header)
+			paths = append(paths, c.path.String())
+		}
+		if !reflect.DeepEqual(header, test.header) || !reflect.DeepEqual(paths, test.paths) {
+			t.Errorf("%s: expected %q %q, found %q %q", test.spec, test.header, test.paths, header, paths)
+		}
+	}
+}
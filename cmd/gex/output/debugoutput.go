@@ -0,0 +1,117 @@
+package output
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/audit"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+// DebugOutput runs a shell in an ephemeral debug container added to
+// a workload pod (kubectl debug). It shares the process namespace of
+// the selected container and the network of the pod, so no privileged
+// pod on the node is required.
+// Ephemeral containers cannot be removed, the terminated debug
+// container stays in the pod spec until the pod is recreated.
+type DebugOutput struct {
+	*KubectlOutput
+	container string
+	image     string
+}
+
+var _ Output = &DebugOutput{}
+
+func NewDebugOutput(args []string, container string, image string, mapper ElementMapper) Output {
+	return &DebugOutput{NewKubectlOutput(args, mapper), container, image}
+}
+
+func (this *DebugOutput) Out(ctx *context.Context) error {
+	cluster := this.Elem.(gube.Cluster)
+	if len(this.GetArgs()) == 0 {
+		return fmt.Errorf("pod name required")
+	}
+	if len(this.GetArgs()) > 1 {
+		return fmt.Errorf("only one pod name possible")
+	}
+	pods, name, err := find_pods(cluster, this.GetArgs()[0])
+	if err != nil {
+		return err
+	}
+	target, ok := pods[name]
+	if !ok {
+		return fmt.Errorf("pod '%s' not found", this.GetArgs()[0])
+	}
+	if target.Status.Phase != corev1.PodRunning {
+		return fmt.Errorf("pod '%s/%s' not running", target.GetNamespace(), name)
+	}
+	container, err := this.targetContainer(&target)
+	if err != nil {
+		return err
+	}
+	image := this.image
+	if image == "" {
+		image = ctx.GetShellConfig(elementGarden(this.Elem)).DebugImage
+	}
+	if err := this.checkSupport(cluster); err != nil {
+		return err
+	}
+	fmt.Printf("debugging container '%s' of pod '%s/%s'\n", container, target.GetNamespace(), name)
+	fmt.Printf("the terminated debug container stays in the pod spec until the pod is recreated\n")
+	audit.Audit(this.Elem, "debug", target.GetNamespace()+"/"+name)
+	return this.Kubectl(nil, "debug", "-it", "-n", target.GetNamespace(), name,
+		"--image="+image, "--target="+container, "--", "/bin/sh")
+}
+
+// checkSupport checks whether kubectl offers the debug command and
+// the cluster supports ephemeral containers (kubernetes 1.23).
+func (this *DebugOutput) checkSupport(cluster gube.Cluster) error {
+	code, err := util.KubectlTo(this.kubecfg, ioutil.Discard, ioutil.Discard, "debug", "--help")
+	if err != nil {
+		return fmt.Errorf("cannot run kubectl: %s", err)
+	}
+	if code != 0 {
+		return fmt.Errorf("kubectl does not support 'kubectl debug', kubectl 1.20 or later required")
+	}
+	clientset, err := cluster.GetClientset()
+	if err != nil {
+		return err
+	}
+	v, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("cannot get cluster version: %s", err)
+	}
+	major, _ := strconv.Atoi(v.Major)
+	minor, _ := strconv.Atoi(strings.TrimRight(v.Minor, "+"))
+	if major < 1 || (major == 1 && minor < 23) {
+		return fmt.Errorf("cluster version %s.%s does not support ephemeral containers, 1.23 or later required", v.Major, v.Minor)
+	}
+	return nil
+}
+
+// targetContainer provides the name of the selected (or first)
+// container of a pod, which must be running.
+func (this *DebugOutput) targetContainer(pod *corev1.Pod) (string, error) {
+	name := this.container
+	if name == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return "", fmt.Errorf("pod '%s' has no containers", pod.GetName())
+		}
+		name = pod.Spec.Containers[0].Name
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == name {
+			if s.State.Running == nil {
+				return "", fmt.Errorf("container '%s' not running", name)
+			}
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("container '%s' not found in pod '%s'", name, pod.GetName())
+}
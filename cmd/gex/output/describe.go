@@ -0,0 +1,94 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+)
+
+// DescribeMode provides the output mode for describe commands selected
+// by the output option: text (default), yaml, json or JSON (compact).
+func DescribeMode(opts *cmdint.Options) (string, error) {
+	f := opts.GetOptionValue(constants.O_OUTPUT)
+	if f == nil {
+		return "", nil
+	}
+	switch *f {
+	case "", "text":
+		return "", nil
+	case "yaml", "json", "JSON":
+		return *f, nil
+	}
+	return "", fmt.Errorf("invalid output format '%s'", *f)
+}
+
+// PrintDescription prints an attribute set describing an element in the
+// given output mode with its secret attributes redacted.
+func PrintDescription(mode string, attrs *util.AttributeSet) error {
+	attrs.MapSecrets(Secret)
+	switch mode {
+	case "yaml":
+		data, err := attrs.YAML()
+		if err != nil {
+			return fmt.Errorf("cannot marshal description: %s", err)
+		}
+		fmt.Printf("---\n%s", data)
+	case "json", "JSON":
+		data, err := attrs.JSON(mode == "json")
+		if err != nil {
+			return fmt.Errorf("cannot marshal description: %s", err)
+		}
+		fmt.Println(string(data))
+	default:
+		fmt.Printf("---\n")
+		attrs.PrintAttributes()
+	}
+	return nil
+}
+
+// DescriptionPrinter prints the descriptions of several elements.
+// In json modes the descriptions are kept until Close and printed
+// as one json array, independent of the number of elements.
+type DescriptionPrinter struct {
+	mode string
+	list []interface{}
+}
+
+func NewDescriptionPrinter(mode string) *DescriptionPrinter {
+	return &DescriptionPrinter{mode: mode}
+}
+
+func (this *DescriptionPrinter) is_json() bool {
+	return this.mode == "json" || this.mode == "JSON"
+}
+
+// Print prints or keeps the description of an element.
+func (this *DescriptionPrinter) Print(attrs *util.AttributeSet) error {
+	if !this.is_json() {
+		return PrintDescription(this.mode, attrs)
+	}
+	attrs.MapSecrets(Secret)
+	this.list = append(this.list, attrs.Data())
+	return nil
+}
+
+// Close prints the kept descriptions.
+func (this *DescriptionPrinter) Close() error {
+	if !this.is_json() {
+		return nil
+	}
+	list := this.list
+	if list == nil {
+		list = []interface{}{}
+	}
+	this.list = nil
+	data, err := util.MarshalJSON(list, this.mode == "json")
+	if err != nil {
+		return fmt.Errorf("cannot marshal description: %s", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,142 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+// ATTR_FIELD is the field of the element data containing the computed
+// element attributes, e.g. .gex.state.
+const ATTR_FIELD = "gex"
+
+type attribute func() interface{}
+
+// element_attributes provides the computed attributes of an element.
+// They are evaluated on demand, because some of them require
+// access to the seed or shoot clusters.
+func element_attributes(e interface{}) map[string]attribute {
+	attrs := map[string]attribute{}
+	if g := elementGarden(e); g != nil {
+		attrs["garden"] = func() interface{} { return g.GetName() }
+	}
+	switch s := e.(type) {
+	case gube.Shoot:
+		attrs["name"] = func() interface{} { return s.GetName().GetName() }
+		attrs["project"] = func() interface{} { return s.GetName().GetProjectName() }
+		attrs["seed"] = func() interface{} { return s.GetSeedName() }
+		attrs["infra"] = func() interface{} { return s.GetInfrastructure() }
+		attrs["profile"] = func() interface{} { return s.GetProfileName() }
+		attrs["region"] = func() interface{} { return s.GetRegion() }
+		attrs["state"] = func() interface{} { return s.GetState() }
+		attrs["error"] = func() interface{} { return s.GetError() }
+		attrs["iaas"] = func() interface{} {
+			info, err := s.GetIaaSInfo()
+			if err != nil {
+				return nil
+			}
+			return info.GetKeyInfo()
+		}
+		attrs["nodes"] = node_count(s)
+	case gube.Seed:
+		attrs["name"] = func() interface{} { return s.GetName() }
+		attrs["infra"] = func() interface{} { return s.GetInfrastructure() }
+		attrs["profile"] = func() interface{} { return s.GetProfileName() }
+		attrs["region"] = func() interface{} { return s.GetRegion() }
+		attrs["nodes"] = node_count(s)
+	case gube.Profile:
+		attrs["name"] = func() interface{} { return s.GetName() }
+		attrs["infra"] = func() interface{} { return s.GetInfrastructure() }
+	case gube.Project:
+		attrs["name"] = func() interface{} { return s.GetName() }
+		attrs["namespace"] = func() interface{} { return s.GetNamespace() }
+		attrs["creator"] = func() interface{} { return s.GetCreator() }
+		attrs["created"] = func() interface{} { return s.GetCreationTimestamp().Format(time.RFC3339) }
+		attrs["quotas"] = func() interface{} {
+			q, err := s.GetQuotas()
+			if err != nil {
+				return nil
+			}
+			a := make([]interface{}, len(q))
+			for i, n := range q {
+				a[i] = n
+			}
+			return a
+		}
+	case gube.GardenConfig:
+		attrs["name"] = func() interface{} { return s.GetName() }
+		attrs["description"] = func() interface{} { return s.GetDescription() }
+		attrs["host"] = func() interface{} {
+			g, err := s.GetGarden()
+			if err != nil {
+				return nil
+			}
+			cfg, err := g.GetClientConfig()
+			if err != nil {
+				return nil
+			}
+			return cfg.Host
+		}
+	}
+	return attrs
+}
+
+func node_count(c gube.Cluster) attribute {
+	return func() interface{} {
+		n, err := c.GetNodeCount()
+		if err != nil {
+			return nil
+		}
+		return n
+	}
+}
+
+var attr_reference = regexp.MustCompile(ATTR_FIELD + `(\.[a-zA-Z]+|\[|\.\*)`)
+
+// referenced_attributes determines the attributes used by a set of
+// JSONPath expressions. Wildcards and recursive descent reference all
+// attributes.
+func referenced_attributes(exprs ...string) (map[string]bool, bool) {
+	names := map[string]bool{}
+	for _, e := range exprs {
+		if strings.Contains(e, "..") {
+			return nil, true
+		}
+		for _, m := range attr_reference.FindAllStringSubmatch(e, -1) {
+			if m[1] == "[" || m[1] == ".*" {
+				return nil, true
+			}
+			names[m[1][1:]] = true
+		}
+	}
+	return names, false
+}
+
+// ElementData provides the manifest of an element as generic json data
+// enriched by the computed attributes below the field "gex". If no
+// manifest is available, the data contains the attributes, only.
+// Only the selected attributes are evaluated, all for a nil selection.
+func ElementData(e interface{}, selected map[string]bool) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if o, ok := e.(gube.RuntimeObjectWrapper); ok {
+		data, err := util.ToJSONData(o.GetRuntimeObject())
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert manifest: %s", err)
+		}
+		if m, ok := data.(map[string]interface{}); ok {
+			result = m
+		}
+	}
+	attrs := map[string]interface{}{}
+	for n, a := range element_attributes(e) {
+		if selected == nil || selected[n] {
+			attrs[n] = a()
+		}
+	}
+	result[ATTR_FIELD] = attrs
+	return result, nil
+}
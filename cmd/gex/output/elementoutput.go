@@ -1,13 +1,21 @@
 package output
 
 import (
+	"sync"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/cleanup"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	. "github.com/afritzler/garden-examiner/pkg/data"
 )
 
 type ElementOutput struct {
 	source ProcessingSource
-	Elems  Iterable
+	Elems  ProcessingResult
+	count  int
+
+	lock     sync.Mutex
+	progress *Progress
+	listener ProgressFunction
 }
 
 func NewElementOutput(chain ProcessChain) *ElementOutput {
@@ -17,15 +25,16 @@ func NewElementOutput(chain ProcessChain) *ElementOutput {
 func (this *ElementOutput) new(chain ProcessChain) *ElementOutput {
 	this.source = NewIncrementalProcessingSource()
 	if chain == nil {
-		this.Elems = this.source
+		this.Elems = Process(this.source)
 	} else {
-		this.Elems = Process(this.source).Asynchronously().Apply(chain)
+		this.Elems = Process(this.source).Asynchronously().WithProgress(this.update).Apply(chain)
 	}
 	return this
 }
 
 func (this *ElementOutput) Add(ctx *context.Context, e interface{}) error {
 	this.source.Add(e)
+	this.count++
 	return nil
 }
 
@@ -36,3 +45,44 @@ func (this *ElementOutput) Close(ctx *context.Context) error {
 
 func (this *ElementOutput) Out(ctx *context.Context) {
 }
+
+func (this *ElementOutput) update(p Progress) {
+	this.lock.Lock()
+	this.progress = &p
+	listener := this.listener
+	this.lock.Unlock()
+	if listener != nil {
+		listener(p)
+	}
+}
+
+// Progress provides the progress of parallel processing steps, if
+// there are any.
+func (this *ElementOutput) Progress() *Progress {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.progress
+}
+
+// OnProgress sets a function called for progress changes.
+func (this *ElementOutput) OnProgress(f ProgressFunction) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.listener = f
+}
+
+// Count provides the number of elements added to the output.
+func (this *ElementOutput) Count() int {
+	return this.count
+}
+
+// Slice provides all processed elements and the processing error.
+// An interrupt cancels the processing, the elements processed so
+// far are still provided.
+func (this *ElementOutput) Slice() (IndexedSliceAccess, error) {
+	done := cleanup.Cancel(this.Elems.Cancel)
+	slice := Slice(this.Elems)
+	err := this.Elems.Error()
+	done()
+	return slice, err
+}
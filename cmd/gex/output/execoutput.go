@@ -0,0 +1,62 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/audit"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+// ExecOutput runs an arbitrary command with KUBECONFIG set to a
+// temporary kubeconfig of the selected cluster.
+type ExecOutput struct {
+	*KubectlOutput
+}
+
+var _ Output = &ExecOutput{}
+
+func NewExecOutput(args []string) Output {
+	return &ExecOutput{NewKubectlOutput(args, nil)}
+}
+
+func (this *ExecOutput) Out(ctx *context.Context) error {
+	args := this.GetArgs()
+	if len(args) == 0 {
+		return fmt.Errorf("no command given")
+	}
+	cfg, err := util.NewTempFileInput(this.kubecfg)
+	if err != nil {
+		return err
+	}
+	defer cfg.CleanupFunction()()
+	_, path := cfg.InheritedFiles(nil)
+
+	garden := ctx.Name
+	if g := elementGarden(this.Elem); g != nil && g.GetName() != "" {
+		garden = g.GetName()
+	}
+	project := ""
+	shoot := ""
+	seed := ""
+	switch e := this.Elem.(type) {
+	case gube.GardenConfig:
+		garden = e.GetName()
+	case gube.Shoot:
+		project = e.GetName().GetProjectName()
+		shoot = e.GetName().String()
+		seed = e.GetSeedName()
+	case gube.Seed:
+		seed = e.GetName()
+	}
+	env := []string{
+		"KUBECONFIG=" + path,
+		"GEX_GARDEN=" + garden,
+		"GEX_PROJECT=" + project,
+		"GEX_SHOOT=" + shoot,
+		"GEX_SEED=" + seed,
+	}
+	audit.Audit(this.Elem, "exec", args[0])
+	return util.ExecProcessEnv(env, args[0], args[1:]...)
+}
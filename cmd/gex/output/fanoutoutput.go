@@ -0,0 +1,150 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/audit"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+// KubectlFanoutOutput runs the same kubectl command for all selected
+// clusters in parallel. The output lines are prefixed with the cluster
+// name and a summary of the exit codes is printed at the end.
+type KubectlFanoutOutput struct {
+	mapper  ElementMapper
+	args    []string
+	targets []*fanout_target
+}
+
+type fanout_target struct {
+	name    string
+	elem    interface{}
+	kubecfg []byte
+	args    []string
+	code    int
+	err     error
+}
+
+var _ Output = &KubectlFanoutOutput{}
+
+func NewKubectlFanoutOutput(args []string, mapper ElementMapper) *KubectlFanoutOutput {
+	return &KubectlFanoutOutput{mapper: mapper, args: args}
+}
+
+func (this *KubectlFanoutOutput) Add(ctx *context.Context, e interface{}) error {
+	t := &fanout_target{name: element_display_name(ctx, e), elem: e, args: this.args}
+	this.targets = append(this.targets, t)
+	if this.mapper != nil {
+		m, args, err := this.mapper(ctx, e)
+		if err != nil {
+			t.err = err
+			return nil
+		}
+		if args != nil {
+			t.args = append(append([]string{}, args...), this.args...)
+		}
+		e = m
+	}
+	t.kubecfg, t.err = e.(gube.KubeconfigProvider).GetKubeconfig()
+	return nil
+}
+
+func (this *KubectlFanoutOutput) Close(ctx *context.Context) error {
+	return nil
+}
+
+func (this *KubectlFanoutOutput) Out(ctx *context.Context) error {
+	if len(this.targets) == 0 {
+		return fmt.Errorf("no clusters selected")
+	}
+	width := 0
+	for _, t := range this.targets {
+		if len(t.name) > width {
+			width = len(t.name)
+		}
+	}
+
+	lock := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	pool := ctx.NewPool(TargetKey)
+	pool.Request()
+	for _, t := range this.targets {
+		if t.err != nil {
+			continue
+		}
+		t := t
+		wg.Add(1)
+		pool.ExecFor(t.elem, func() {
+			defer wg.Done()
+			prefix := fmt.Sprintf("%-*s | ", width, t.name)
+			stdout := util.NewPrefixWriter(os.Stdout, lock, prefix)
+			stderr := util.NewPrefixWriter(os.Stderr, lock, prefix)
+			audit.Audit(t.elem, "kubectl", t.name)
+			t.code, t.err = util.KubectlTo(t.kubecfg, stdout, stderr, t.args...)
+			stdout.Close()
+			stderr.Close()
+		})
+	}
+	wg.Wait()
+	pool.Release()
+
+	sort.Slice(this.targets, func(i, j int) bool { return this.targets[i].name < this.targets[j].name })
+	failed := 0
+	table := [][]string{[]string{"CLUSTER", "-EXIT", "ERROR"}}
+	for _, t := range this.targets {
+		code := fmt.Sprintf("%d", t.code)
+		msg := ""
+		if t.err != nil {
+			code = "-"
+			msg = util.Oneline(t.err.Error(), 90)
+		}
+		if t.err != nil || t.code != 0 {
+			failed++
+		}
+		table = append(table, []string{t.name, code, msg})
+	}
+	fmt.Println()
+	util.FormatTable("", table)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d invocations failed", failed, len(this.targets))
+	}
+	return nil
+}
+
+func element_display_name(ctx *context.Context, e interface{}) string {
+	switch s := e.(type) {
+	case gube.Shoot:
+		return ctx.DisplayName(s.Garden(), s.GetName().String())
+	case gube.Seed:
+		return ctx.DisplayName(s.Garden(), s.GetName())
+	}
+	return audit.ElementName(e)
+}
+
+// TargetKey provides the key used to limit the parallel requests
+// for an element. This is the seed for shoots and the garden for
+// gardens. Seeds are qualified by their garden (<garden>:<seed>),
+// because seeds of different gardens may use the same name.
+func TargetKey(e interface{}) interface{} {
+	switch s := e.(type) {
+	case gube.Shoot:
+		return seed_key(s.Garden(), s.GetSeedName())
+	case gube.Seed:
+		return seed_key(s.Garden(), s.GetName())
+	case gube.GardenConfig:
+		return s.GetName()
+	}
+	return nil
+}
+
+func seed_key(g gube.Garden, seed string) string {
+	if g == nil {
+		return ":" + seed
+	}
+	return g.GetName() + ":" + seed
+}
@@ -3,6 +3,7 @@ package output
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
@@ -100,14 +101,24 @@ func (this Outputs) Select(name string) OutputFactory {
 	return c
 }
 
+// Create creates the output selected by the output option. The option
+// value may contain an argument for the output separated by =
+// (see OutputArgument).
 func (this Outputs) Create(opts *cmdint.Options) (Output, error) {
 	f := opts.GetOptionValue(constants.O_OUTPUT)
 	if f == nil {
 		return this[""](opts), nil
 	}
-	c := this.Select(*f)
+	name := *f
+	if i := strings.Index(name, "="); i >= 0 {
+		name = name[:i]
+	}
+	c := this.Select(name)
 	if c != nil {
 		o := c(opts)
+		if e, ok := o.(*ErrorOutput); ok {
+			return nil, e.err
+		}
 		if o != nil {
 			return o, nil
 		}
@@ -115,6 +126,39 @@ func (this Outputs) Create(opts *cmdint.Options) (Output, error) {
 	return nil, fmt.Errorf("invalid output format '%s'", *f)
 }
 
+// OutputArgument provides the argument given for the selected output
+// mode in the form <mode>=<argument>.
+func OutputArgument(opts *cmdint.Options) string {
+	f := opts.GetOptionValue(constants.O_OUTPUT)
+	if f == nil {
+		return ""
+	}
+	if i := strings.Index(*f, "="); i >= 0 {
+		return (*f)[i+1:]
+	}
+	return ""
+}
+
+// ErrorOutput is provided by output factories for invalid output
+// arguments.
+type ErrorOutput struct {
+	err error
+}
+
+func NewErrorOutput(err error) Output {
+	return &ErrorOutput{err}
+}
+
+func (this *ErrorOutput) Add(ctx *context.Context, e interface{}) error {
+	return this.err
+}
+func (this *ErrorOutput) Close(ctx *context.Context) error {
+	return this.err
+}
+func (this *ErrorOutput) Out(*context.Context) error {
+	return this.err
+}
+
 func (this Outputs) AddManifestOutputs() Outputs {
 	this["yaml"] = func(opts *cmdint.Options) Output {
 		return &YAMLOutput{ManifestOutput{data: []runtime.Object{}}}
@@ -125,6 +169,16 @@ func (this Outputs) AddManifestOutputs() Outputs {
 	this["JSON"] = func(opts *cmdint.Options) Output {
 		return &JSONOutput{ManifestOutput{data: []runtime.Object{}}, false}
 	}
+	return this.AddElementDataOutputs().AddTableFormats()
+}
+
+// AddElementDataOutputs adds the output modes evaluating JSONPath
+// expressions or go templates on the element data.
+func (this Outputs) AddElementDataOutputs() Outputs {
+	this[CUSTOM_COLUMNS] = CustomColumnsOutputFactory
+	this[JSONPATH] = JSONPathOutputFactory
+	this[GO_TEMPLATE] = GoTemplateOutputFactory
+	this[GO_TEMPLATE_FILE] = GoTemplateFileOutputFactory
 	return this
 }
 
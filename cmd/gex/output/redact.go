@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+)
+
+const REDACTED = "<redacted>"
+
+var showSecrets = false
+
+// ShowSecrets enables or disables the output of credentials and other
+// sensitive values. By default all of them are redacted.
+func ShowSecrets(show bool) {
+	showSecrets = show
+}
+
+func IsShowSecrets() bool {
+	return showSecrets
+}
+
+// Secret redacts a sensitive value, if secrets should not be shown.
+func Secret(value string) string {
+	if showSecrets || value == "" {
+		return value
+	}
+	return REDACTED
+}
+
+// IsSensitiveKey checks whether a field name looks like it
+// holds a credential.
+func IsSensitiveKey(key string) bool {
+	return sensitive_key.MatchString(key)
+}
+
+var sensitive_key = regexp.MustCompile(`(?i)(password|passwd|secret|token|private_?key|access_?key|credential|client_?key)`)
+
+////////////////////////////////////////////////////////////////////////////
+// text based redaction
+
+var text_assignment = regexp.MustCompile(`(?m)^(\s*"?([A-Za-z0-9_.-]+)"?\s*[=:]\s*)"([^"]*)"`)
+var text_variable = regexp.MustCompile(`(?s)variable\s+"([^"]+)"\s*\{.*?\n\}`)
+var text_default = regexp.MustCompile(`(?m)^(\s*default\s*=\s*)"([^"]*)"`)
+
+// RedactText redacts values of assignments in HCL, YAML or
+// JSON like text documents if the assigned key or the
+// enclosing terraform variable looks sensitive.
+func RedactText(text string) string {
+	if showSecrets {
+		return text
+	}
+	text = text_variable.ReplaceAllStringFunc(text, func(v string) string {
+		name := text_variable.FindStringSubmatch(v)[1]
+		if !IsSensitiveKey(name) {
+			return v
+		}
+		return text_default.ReplaceAllString(v, `${1}"`+REDACTED+`"`)
+	})
+	return text_assignment.ReplaceAllStringFunc(text, func(a string) string {
+		m := text_assignment.FindStringSubmatch(a)
+		if !IsSensitiveKey(m[2]) || m[3] == "" {
+			return a
+		}
+		return m[1] + `"` + REDACTED + `"`
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////
+// terraform state redaction
+
+// RedactTerraformState redacts all outputs marked as sensitive and
+// all resource attributes looking like credentials from a terraform
+// state document.
+func RedactTerraformState(state string) string {
+	if showSecrets {
+		return state
+	}
+	data := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(state), &data); err != nil {
+		return RedactText(state)
+	}
+	modules, _ := data["modules"].([]interface{})
+	for _, m := range modules {
+		module, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		outputs, _ := module["outputs"].(map[string]interface{})
+		for k, o := range outputs {
+			output, ok := o.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if s, _ := output["sensitive"].(bool); s || IsSensitiveKey(k) {
+				output["value"] = REDACTED
+			}
+		}
+		resources, _ := module["resources"].(map[string]interface{})
+		for _, r := range resources {
+			resource, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			primary, _ := resource["primary"].(map[string]interface{})
+			attributes, _ := primary["attributes"].(map[string]interface{})
+			for k := range attributes {
+				if IsSensitiveKey(k) {
+					attributes[k] = REDACTED
+				}
+			}
+		}
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(data); err != nil {
+		return RedactText(state)
+	}
+	return buf.String()
+}
@@ -2,14 +2,19 @@ package output
 
 import (
 	"fmt"
+	"os/user"
+	"regexp"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
+	"github.com/afritzler/garden-examiner/cmd/gex/audit"
+	"github.com/afritzler/garden-examiner/cmd/gex/cleanup"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
@@ -17,21 +22,69 @@ import (
 
 type ShellOutput struct {
 	*KubectlOutput
+	reap_only bool
 }
 
 var _ Output = &ShellOutput{}
 
 func NewShellOutput(node *string, pod *string, mapper ElementMapper) Output {
 	args := []string{util.StringValue(node), util.StringValue(pod)}
-	return &ShellOutput{NewKubectlOutput(args, mapper)}
+	return &ShellOutput{NewKubectlOutput(args, mapper), false}
+}
+
+// NewShellReapOutput provides an output deleting abandoned shell pods
+// of the actual user instead of running a shell.
+func NewShellReapOutput(mapper ElementMapper) Output {
+	return &ShellOutput{NewKubectlOutput(nil, mapper), true}
 }
 
 func (this *ShellOutput) Out(ctx *context.Context) error {
 	cluster := this.Elem.(gube.Cluster)
-	nodes, err := cluster.GetNodes()
+	if this.reap_only {
+		return this.reap(ctx, cluster)
+	}
+	lookupNode := ""
+	lookupPod := ""
+	if len(this.GetArgs()) > 0 {
+		lookupNode = this.GetArgs()[0]
+	}
+	if len(this.GetArgs()) > 1 {
+		lookupPod = this.GetArgs()[1]
+	}
+	name, nodes, err := select_node(cluster, lookupNode, lookupPod)
+	if name == "" || err != nil {
+		return err
+	}
+	client, err := cluster.GetClientset()
+	if err != nil {
+		return fmt.Errorf("cannot access cluster: %s", err)
+	}
+	cfg := ctx.GetShellConfig(elementGarden(this.Elem))
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return fmt.Errorf("invalid shell timeout '%s': %s", cfg.Timeout, err)
+	}
+	fmt.Printf("running shell on node '%s'\n", name)
+	audit.Audit(this.Elem, "shell", name)
+
+	node := nodes[name]
+	hostname := get_label(node.GetObjectMeta(), "kubernetes.io/hostname")
+	podname, done, err := start_pod(client.CoreV1().Pods(cfg.Namespace), shell_pod(cfg, hostname), timeout)
 	if err != nil {
 		return err
 	}
+	defer done()
+	return this.Kubectl(nil, "exec", "-it", "-n", cfg.Namespace, podname, "--", "/bin/sh", "-c", "chroot /hostroot")
+}
+
+// select_node selects a node of a cluster by a node name (suffix) or
+// the node of a pod. If nothing is selected, the node names are listed
+// and the empty name is returned.
+func select_node(cluster gube.Cluster, lookupNode string, lookupPod string) (string, map[string]corev1.Node, error) {
+	nodes, err := cluster.GetNodes()
+	if err != nil {
+		return "", nil, err
+	}
 
 	hostnames := map[string]string{}
 	nodenames := []string{}
@@ -40,12 +93,6 @@ func (this *ShellOutput) Out(ctx *context.Context) error {
 	podnames := []string{}
 
 	name := ""
-	lookupNode := ""
-	lookupPod := ""
-
-	if len(this.GetArgs()) > 0 {
-		lookupNode = this.GetArgs()[0]
-	}
 
 	for _, n := range nodes {
 		host := get_label(n.GetObjectMeta(), "kubernetes.io/hostname")
@@ -56,24 +103,15 @@ func (this *ShellOutput) Out(ctx *context.Context) error {
 		hostnames[n.GetName()] = host
 	}
 
-	if len(this.GetArgs()) > 1 {
-		lookupPod = this.GetArgs()[1]
-		if lookupPod != "" {
-			var pods map[string]corev1.Pod
-			if i := strings.Index(lookupPod, "/"); i > 0 {
-				ns := lookupPod[0:i]
-				lookupPod = lookupPod[i+1:]
-				pods, err = cluster.GetPods(ns)
-			} else {
-				pods, err = cluster.GetPods("")
-			}
-			if err != nil {
-				return err
-			}
-			for _, n := range pods {
-				podnames = append(podnames, n.GetName())
-				podnodes[n.GetName()] = n.Spec.NodeName
-			}
+	if lookupPod != "" {
+		var pods map[string]corev1.Pod
+		pods, lookupPod, err = find_pods(cluster, lookupPod)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, n := range pods {
+			podnames = append(podnames, n.GetName())
+			podnodes[n.GetName()] = n.Spec.NodeName
 		}
 	}
 
@@ -91,37 +129,87 @@ func (this *ShellOutput) Out(ctx *context.Context) error {
 			fmt.Printf("- %s (%s)\n", n, hostnames[n])
 		}
 		if lookupNode == "" {
-			return nil
+			return "", nodes, nil
+		}
+		return "", nodes, fmt.Errorf("node '%s' not found", lookupNode)
+	}
+	return name, nodes, nil
+}
+
+// find_pods provides the pods matching a pod lookup of the form
+// [<namespace>/]<pod>, together with the pod name part of the lookup.
+func find_pods(cluster gube.Cluster, lookup string) (map[string]corev1.Pod, string, error) {
+	ns := ""
+	if i := strings.Index(lookup, "/"); i > 0 {
+		ns = lookup[0:i]
+		lookup = lookup[i+1:]
+	}
+	pods, err := cluster.GetPods(ns)
+	return pods, lookup, err
+}
+
+// start_pod creates a pod and waits until it is running. The returned
+// function deletes the pod again. It is also registered for cleanup,
+// so the pod is removed if gex is interrupted.
+func start_pod(pods typedcorev1.PodInterface, pod *corev1.Pod, timeout time.Duration) (string, func(), error) {
+	pod, err := pods.Create(pod)
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot create pod: %s", err)
+	}
+	podname := pod.GetName()
+	done := cleanup.Cleanup(func() {
+		delete_shell_pod(pods, podname)
+	})
+
+	fmt.Printf("waiting for pod '%s/%s'\n", pod.GetNamespace(), podname)
+	limit := time.Now().Add(timeout)
+	for {
+		pod, err = pods.Get(podname, metav1.GetOptions{})
+		if err != nil {
+			done()
+			return "", nil, fmt.Errorf("pod not found: %s", err)
+		}
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.HostIP != "" {
+			fmt.Printf("host ip found: %s\n", pod.Status.HostIP)
+			return podname, done, nil
 		}
-		return fmt.Errorf("node '%s' not found", lookupNode)
+		if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+			done()
+			return "", nil, fmt.Errorf("pod terminated: %s", pod.Status.Phase)
+		}
+		if time.Now().After(limit) {
+			done()
+			return "", nil, fmt.Errorf("pod not running after %s", timeout)
+		}
+		time.Sleep(500 * time.Millisecond)
 	}
+}
+
+// reap deletes all shell pods of the actual user left over by
+// aborted shell sessions.
+func (this *ShellOutput) reap(ctx *context.Context, cluster gube.Cluster) error {
 	client, err := cluster.GetClientset()
 	if err != nil {
 		return fmt.Errorf("cannot access cluster: %s", err)
 	}
-	fmt.Printf("running shell on node '%s'\n", name)
-	_, err = client.CoreV1().Pods("default").Get("rootpod", metav1.GetOptions{})
-	if err == nil {
-		this.Kubectl(nil, "delete", "pod", "rootpod")
+	cfg := ctx.GetShellConfig(elementGarden(this.Elem))
+	pods := client.CoreV1().Pods(cfg.Namespace)
+	list, err := pods.List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%s", SHELL_LABEL_APP, SHELL_APP, SHELL_LABEL_OWNER, shell_owner()),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot list shell pods: %s", err)
 	}
-	manifest := strings.Replace(shell_manifest, "HOSTNAME", hostnames[name], -1)
-	this.Kubectl([]byte(manifest), "apply", "-f", "-")
-
-	for true {
-		pod, err := client.CoreV1().Pods("default").Get("rootpod", metav1.GetOptions{})
+	if len(list.Items) == 0 {
+		fmt.Printf("no shell pods found\n")
+	}
+	for _, p := range list.Items {
+		fmt.Printf("deleting shell pod '%s/%s' (node %s)\n", p.GetNamespace(), p.GetName(), p.Spec.NodeName)
+		err = delete_shell_pod(pods, p.GetName())
 		if err != nil {
-			fmt.Printf("pod not found: %s\n", err)
-		} else {
-			ip := pod.Status.HostIP
-			if ip != "" && pod.Status.Phase == "Running" {
-				fmt.Printf("host ip found: %s\n", ip)
-				break
-			}
-			time.Sleep(500 * time.Millisecond)
+			return fmt.Errorf("cannot delete shell pod '%s': %s", p.GetName(), err)
 		}
 	}
-	this.Kubectl(nil, "exec", "-it", "rootpod", "--", "/bin/sh", "-c", "chroot /hostroot")
-	this.Kubectl(nil, "delete", "pod", "rootpod")
 	return nil
 }
 
@@ -129,33 +217,98 @@ func get_label(n metav1.Object, label string) string {
 	return n.GetLabels()[label]
 }
 
-var shell_manifest = `
-apiVersion: v1
-kind: Pod
-metadata:
-  name: rootpod
-spec:
-  containers:
-  - image: busybox
-    securityContext:
-      privileged: true
-    name: root-container
-    command:
-    - sleep 
-    - "10000000"
-    volumeMounts:
-    - mountPath: /hostroot
-      name: root-volume
-  hostNetwork: true
-  hostPID: true
-  nodeSelector:
-    kubernetes.io/hostname: "HOSTNAME"
-  tolerations:
-  - key: node-role.kubernetes.io/master
-    operator: Exists
-    effect: NoSchedule
-  volumes:
-  - name: root-volume
-    hostPath:
-      path: /
-`
+const (
+	SHELL_APP         = "gex-shell"
+	SHELL_LABEL_APP   = "app"
+	SHELL_LABEL_OWNER = "gex.gardener.cloud/owner"
+)
+
+func elementGarden(e interface{}) gube.Garden {
+	switch g := e.(type) {
+	case gube.Garden:
+		return g
+	case interface {
+		Garden() gube.Garden
+	}:
+		return g.Garden()
+	}
+	return nil
+}
+
+var invalid_label_chars = regexp.MustCompile("[^a-z0-9-]+")
+
+// shell_owner provides the owner label value for the actual user.
+func shell_owner() string {
+	name := ""
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	name = strings.Trim(invalid_label_chars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > 30 {
+		name = strings.Trim(name[:30], "-")
+	}
+	if name == "" {
+		name = "unknown"
+	}
+	return name
+}
+
+// shell_pod_meta provides the metadata for pods created by gex. They are
+// labeled with the owner to be found by a reap.
+func shell_pod_meta(cfg *gube.ShellConfig) metav1.ObjectMeta {
+	owner := shell_owner()
+	return metav1.ObjectMeta{
+		GenerateName: fmt.Sprintf("%s-%s-", SHELL_APP, owner),
+		Namespace:    cfg.Namespace,
+		Labels: map[string]string{
+			SHELL_LABEL_APP:   SHELL_APP,
+			SHELL_LABEL_OWNER: owner,
+		},
+	}
+}
+
+func shell_pod(cfg *gube.ShellConfig, hostname string) *corev1.Pod {
+	privileged := true
+	automount := false
+	grace := int64(0)
+	return &corev1.Pod{
+		ObjectMeta: shell_pod_meta(cfg),
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				corev1.Container{
+					Name:    "root-container",
+					Image:   cfg.Image,
+					Command: []string{"sleep", "10000000"},
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: &privileged,
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						corev1.VolumeMount{Name: "root-volume", MountPath: "/hostroot"},
+					},
+				},
+			},
+			HostNetwork:                   true,
+			HostPID:                       true,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			AutomountServiceAccountToken:  &automount,
+			TerminationGracePeriodSeconds: &grace,
+			NodeSelector: map[string]string{
+				"kubernetes.io/hostname": hostname,
+			},
+			Tolerations: cfg.Tolerations,
+			Volumes: []corev1.Volume{
+				corev1.Volume{
+					Name: "root-volume",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func delete_shell_pod(pods typedcorev1.PodInterface, name string) error {
+	grace := int64(0)
+	return pods.Delete(name, &metav1.DeleteOptions{GracePeriodSeconds: &grace})
+}
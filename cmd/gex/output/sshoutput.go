@@ -0,0 +1,139 @@
+package output
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/audit"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+)
+
+const SSH_KEYPAIR_SECRET = "ssh-keypair"
+const SSH_PRIVATE_KEY = "id_rsa"
+
+// SSHOutput opens an ssh session to a shoot node. The connection is
+// tunneled through a temporary jump pod in the shoot cluster.
+type SSHOutput struct {
+	*KubectlOutput
+	user string
+}
+
+var _ Output = &SSHOutput{}
+
+func NewSSHOutput(args []string, user string) Output {
+	if user == "" {
+		user = "core"
+	}
+	return &SSHOutput{NewKubectlOutput(args, nil), user}
+}
+
+func (this *SSHOutput) Out(ctx *context.Context) error {
+	shoot := this.Elem.(gube.Shoot)
+	lookup := ""
+	cmd := []string{}
+	if len(this.GetArgs()) > 0 {
+		lookup = this.GetArgs()[0]
+		cmd = this.GetArgs()[1:]
+	}
+	name, nodes, err := select_node(shoot, lookup, "")
+	if name == "" || err != nil {
+		return err
+	}
+	ip := ""
+	for _, a := range nodes[name].Status.Addresses {
+		if a.Type == corev1.NodeInternalIP {
+			ip = a.Address
+			break
+		}
+	}
+	if ip == "" {
+		return fmt.Errorf("no internal ip found for node '%s'", name)
+	}
+
+	keys, err := shoot.GetSecretContentFromSeed(SSH_KEYPAIR_SECRET)
+	if err != nil {
+		return fmt.Errorf("cannot get ssh key pair: %s", err)
+	}
+	key := keys[SSH_PRIVATE_KEY]
+	if key == "" {
+		return fmt.Errorf("no private key found in secret '%s'", SSH_KEYPAIR_SECRET)
+	}
+	if info, err := shoot.GetIaaSInfo(); err == nil {
+		if access, ok := info.(gube.NodeAccessInfo); ok {
+			if access.GetKeyName() != "" {
+				fmt.Printf("using key pair '%s'\n", access.GetKeyName())
+			}
+			if access.GetNodesSecurityGroup() != "" {
+				fmt.Printf("ssh must be permitted by '%s' for the cluster network\n", access.GetNodesSecurityGroup())
+			}
+		}
+	}
+
+	client, err := shoot.GetClientset()
+	if err != nil {
+		return fmt.Errorf("cannot access cluster: %s", err)
+	}
+	cfg := ctx.GetShellConfig(shoot.Garden())
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return fmt.Errorf("invalid shell timeout '%s': %s", cfg.Timeout, err)
+	}
+	fmt.Printf("opening ssh session to node '%s' (%s)\n", name, ip)
+	audit.Audit(this.Elem, "ssh", name)
+
+	podname, done, err := start_pod(client.CoreV1().Pods(cfg.Namespace), jump_pod(cfg), timeout)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	keyfile, err := util.NewTempFileInput([]byte(key))
+	if err != nil {
+		return err
+	}
+	defer keyfile.CleanupFunction()()
+	_, keypath := keyfile.InheritedFiles(nil)
+
+	kubecfg, err := util.NewTempFileInput(this.kubecfg)
+	if err != nil {
+		return err
+	}
+	defer kubecfg.CleanupFunction()()
+	_, cfgpath := kubecfg.InheritedFiles(nil)
+
+	proxy := fmt.Sprintf("kubectl --kubeconfig=%s exec -i -n %s %s -- nc %%h %%p", cfgpath, cfg.Namespace, podname)
+	args := []string{
+		"-i", keypath,
+		"-o", "IdentitiesOnly=yes",
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "ProxyCommand=" + proxy,
+		fmt.Sprintf("%s@%s", this.user, ip),
+	}
+	return util.ExecProcess(nil, nil, "ssh", append(args, cmd...)...)
+}
+
+func jump_pod(cfg *gube.ShellConfig) *corev1.Pod {
+	automount := false
+	grace := int64(0)
+	return &corev1.Pod{
+		ObjectMeta: shell_pod_meta(cfg),
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				corev1.Container{
+					Name:    "jump-container",
+					Image:   cfg.Image,
+					Command: []string{"sleep", "10000000"},
+				},
+			},
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			AutomountServiceAccountToken:  &automount,
+			TerminationGracePeriodSeconds: &grace,
+			Tolerations:                   cfg.Tolerations,
+		},
+	}
+}
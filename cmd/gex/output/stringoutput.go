@@ -20,9 +20,20 @@ func NewStringOutput(mapper data.MappingFunction, linesep string) *StringOutput
 	return (&StringOutput{}).new(mapper, linesep)
 }
 
+// NewStringOutputWithError provides a string output for a mapper
+// reporting errors. The failed elements are dropped and their errors
+// are reported after the strings of the other elements.
+func NewStringOutputWithError(mapper data.ErrorMappingFunction, linesep string) *StringOutput {
+	return (&StringOutput{}).new_chain(data.Chain().Parallel(20).WithErrorPolicy(data.COLLECT_ERRORS).MapWithError(mapper), linesep)
+}
+
 func (this *StringOutput) new(mapper data.MappingFunction, lineseperator string) *StringOutput {
+	return this.new_chain(data.Chain().Parallel(20).Map(mapper), lineseperator)
+}
+
+func (this *StringOutput) new_chain(chain data.ProcessChain, lineseperator string) *StringOutput {
 	this.linesep = lineseperator
-	this.ElementOutput.new(data.Chain().Parallel(20).Map(mapper))
+	this.ElementOutput.new(chain)
 	return this
 }
 
@@ -49,5 +60,8 @@ func (this *StringOutput) Out(ctx *context.Context) error {
 			}
 		}
 	}
+	if err == nil {
+		err = this.Elems.Error()
+	}
 	return err
 }
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"os"
+	"strings"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+)
+
+// terminal styles (SGR parameters)
+const (
+	STYLE_NONE    = ""
+	STYLE_BOLD    = "1"
+	STYLE_DIM     = "2"
+	STYLE_RED     = "31"
+	STYLE_GREEN   = "32"
+	STYLE_YELLOW  = "33"
+	STYLE_CYAN    = "36"
+	STYLE_FAILURE = "1;31"
+)
+
+var colors = false
+
+// EnableColors enables styled output. It is only used if the standard
+// output is a terminal and the NO_COLOR environment variable is not set.
+func EnableColors(enable bool) {
+	colors = enable && util.IsTerminal() && os.Getenv("NO_COLOR") == ""
+}
+
+func IsColored() bool {
+	return colors
+}
+
+// Styled applies a style to a string, if styled output is enabled.
+func Styled(style, s string) string {
+	if !colors || style == STYLE_NONE || s == "" {
+		return s
+	}
+	return "\033[" + style + "m" + s + "\033[0m"
+}
+
+// StateStyle provides the style for an element state.
+func StateStyle(state string) string {
+	switch strings.ToLower(state) {
+	case "succeeded", "ready":
+		return STYLE_GREEN
+	case "processing", "pending":
+		return STYLE_CYAN
+	case "problem", "timeout":
+		return STYLE_YELLOW
+	case "error", "failed":
+		return STYLE_FAILURE
+	case "unknown", "aborted":
+		return STYLE_DIM
+	}
+	return STYLE_NONE
+}
+
+func StyleState(state string) string {
+	return Styled(StateStyle(state), state)
+}
+
+func StyleError(msg string) string {
+	return Styled(STYLE_RED, msg)
+}
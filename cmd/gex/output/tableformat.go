@@ -0,0 +1,66 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+)
+
+// table_format_factory provides an output rendering a table output mode
+// of the given outputs in the given format. The table mode is given as
+// output argument, e.g. csv=wide or csv=custom-columns=<spec>.
+// Without argument the default output mode is used.
+func table_format_factory(outputs Outputs, format util.TableFormat) OutputFactory {
+	return func(opts *cmdint.Options) Output {
+		mode := OutputArgument(opts)
+		name := mode
+		if i := strings.Index(name, "="); i >= 0 {
+			name = name[:i]
+		}
+		if _, ok := util.TableFormats[name]; ok {
+			return NewErrorOutput(fmt.Errorf("invalid table mode '%s'", name))
+		}
+		c := outputs.Select(name)
+		if c == nil {
+			return NewErrorOutput(fmt.Errorf("invalid table mode '%s'", name))
+		}
+		o := c(with_output(opts, mode))
+		if e, ok := o.(*ErrorOutput); ok {
+			return e
+		}
+		t, ok := o.(*TableProcessingOutput)
+		if !ok {
+			return NewErrorOutput(fmt.Errorf("output mode '%s' is no table", name))
+		}
+		return t.WithFormat(format)
+	}
+}
+
+// with_output provides a copy of the options with a replaced
+// output option used to create nested outputs.
+func with_output(opts *cmdint.Options, mode string) *cmdint.Options {
+	n := *opts
+	n.SingleArgumentOptions = map[string]string{}
+	for k, v := range opts.SingleArgumentOptions {
+		n.SingleArgumentOptions[k] = v
+	}
+	if mode == "" {
+		delete(n.SingleArgumentOptions, constants.O_OUTPUT)
+	} else {
+		n.SingleArgumentOptions[constants.O_OUTPUT] = mode
+	}
+	return &n
+}
+
+// AddTableFormats adds the output modes rendering the table output
+// modes as csv, tsv, markdown or ndjson.
+func (this Outputs) AddTableFormats() Outputs {
+	for n, f := range util.TableFormats {
+		this[n] = table_format_factory(this, f)
+	}
+	return this
+}
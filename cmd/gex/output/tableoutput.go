@@ -1,11 +1,14 @@
 package output
 
 import (
+	gocontext "context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
+	"github.com/afritzler/garden-examiner/cmd/gex/cleanup"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
@@ -14,59 +17,162 @@ import (
 
 type TableProcessingOutput struct {
 	ElementOutput
-	header []string
-	opts   *cmdint.Options
+	header  []string
+	columns []column
+	widths  []int
+	format  util.TableFormat
+	opts    *cmdint.Options
 }
 
 var _ Output = &TableProcessingOutput{}
 
+// NewProcessingTableOutput provides a table output for the given header
+// fields. A leading - aligns a column right, a suffix :<type> declares
+// the column type used for sorting (number, time, version or state).
 func NewProcessingTableOutput(opts *cmdint.Options, chain ProcessChain, header ...string) *TableProcessingOutput {
 	return (&TableProcessingOutput{}).new(opts, chain, header)
 }
 
 func (this *TableProcessingOutput) new(opts *cmdint.Options, chain ProcessChain, header []string) *TableProcessingOutput {
-	this.header = header
+	this.header, this.columns = parse_header(header)
 	this.ElementOutput.new(chain)
 	this.opts = opts
 	return this
 }
 
+// WithWidths sets column width hints and enables streamed output
+// on terminals. It is intended for outputs with slow element
+// processing, all other outputs are formatted after the processing.
+func (this *TableProcessingOutput) WithWidths(widths ...int) *TableProcessingOutput {
+	this.widths = widths
+	return this
+}
+
+// WithFormat sets a format used to render the table instead of
+// a space padded table.
+func (this *TableProcessingOutput) WithFormat(format util.TableFormat) *TableProcessingOutput {
+	this.format = format
+	return this
+}
+
 func (this *TableProcessingOutput) Out(*context.Context) error {
 	lines := [][]string{this.header}
 
 	sort := this.opts.GetArrayOptionValue(constants.O_SORT)
-	slice := Slice(this.Elems)
-	if sort != nil {
-		cols := make([]string, len(this.header))
-		idxs := map[string]int{}
-		for i, n := range this.header {
-			cols[i] = strings.ToLower(n)
-			if strings.HasPrefix(cols[i], "-") {
-				cols[i] = cols[i][1:]
-			}
-			idxs[cols[i]] = i
+	if this.widths != nil && sort == nil && this.format == nil && util.IsTerminal() {
+		return this.stream()
+	}
+	rows, err := this.Rows()
+	if rows == nil {
+		return err
+	}
+	if this.format != nil {
+		if ferr := this.format(os.Stdout, this.header, rows, this.opts.IsFlag(constants.O_NOHEADERS)); ferr != nil {
+			return ferr
 		}
-		for _, k := range sort {
-			key, _ := cmdint.SelectBest(strings.ToLower(k), cols...)
-			if key == "" {
-				return fmt.Errorf("unknown field '%s'", k)
+		return err
+	}
+	for i, r := range rows {
+		rows[i] = this.StyleRow(r)
+	}
+	util.FormatTable("", append(lines, rows...))
+	return err
+}
+
+// StyleRow provides a copy of a row with styled state and
+// error columns (see EnableColors).
+func (this *TableProcessingOutput) StyleRow(row []string) []string {
+	if !IsColored() {
+		return row
+	}
+	styled := make([]string, len(row))
+	for i, c := range row {
+		styled[i] = c
+		if i < len(this.columns) {
+			switch {
+			case this.columns[i].ctype == COL_STATE:
+				styled[i] = StyleState(c)
+			case this.columns[i].name == "error":
+				styled[i] = StyleError(c)
 			}
-			slice.Sort(compare_column(idxs[key]))
 		}
 	}
+	return styled
+}
 
-	util.FormatTable("", append(lines, StringArraySlice(slice)...))
-	return nil
+// Header provides the header fields, a leading - denotes a right
+// aligned column.
+func (this *TableProcessingOutput) Header() []string {
+	return this.header
 }
 
-func compare_column(c int) CompareFunction {
-	return func(a interface{}, b interface{}) int {
-		aa := a.([]string)
-		ab := b.([]string)
-		if len(aa) > c && len(ab) > c {
-			return strings.Compare(aa[c], ab[c])
+// Format provides the format set with WithFormat.
+func (this *TableProcessingOutput) Format() util.TableFormat {
+	return this.format
+}
+
+// Rows provides the table rows in the requested sort order. For
+// collected errors (see COLLECT_ERRORS) or a cancelled processing
+// the rows processed successfully are provided together with
+// the error.
+func (this *TableProcessingOutput) Rows() ([][]string, error) {
+	slice, err := this.ElementOutput.Slice()
+	if err != nil && !partial_result(err) {
+		return nil, err
+	}
+	sort := this.opts.GetArrayOptionValue(constants.O_SORT)
+	if sort != nil {
+		cmp, err := sort_comparator(this.columns, sort)
+		if err != nil {
+			return nil, err
+		}
+		if cmp != nil {
+			slice.SortStable(cmp)
 		}
-		return len(aa) - len(ab)
 	}
+	return StringArraySlice(slice), err
+}
+
+// partial_result checks whether a processing error still provides
+// a usable result.
+func partial_result(err error) bool {
+	if _, ok := err.(ProcessingErrors); ok {
+		return true
+	}
+	return err == gocontext.Canceled
+}
 
+// stream prints the rows as soon as they are available in the
+// processing order, followed by a progress footer.
+func (this *TableProcessingOutput) stream() error {
+	done := cleanup.Cancel(this.Elems.Cancel)
+	defer done()
+
+	table := util.NewTableStream("", this.header, this.widths...)
+	kind := "elements"
+	if len(this.header) > 0 {
+		kind = strings.ToLower(strings.TrimPrefix(this.header[0], "-")) + "s"
+	}
+	progress := func() {
+		p := this.Progress()
+		if p == nil {
+			table.Footer(fmt.Sprintf("%d/%d %s processed", table.Rows(), this.Count(), kind))
+			return
+		}
+		msg := fmt.Sprintf("%d/%d %s processed", p.Done, this.Count(), kind)
+		if p.TimedOut > 0 {
+			msg = fmt.Sprintf("%s, %d timed out", msg, p.TimedOut)
+		}
+		table.Footer(msg)
+	}
+	progress()
+	this.OnProgress(func(Progress) { progress() })
+	it := this.Elems.Iterator()
+	for it.HasNext() {
+		table.Row(this.StyleRow(it.Next().([]string)))
+		progress()
+	}
+	this.OnProgress(nil)
+	table.Close()
+	return this.Elems.Error()
 }
@@ -0,0 +1,281 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"text/template"
+
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+	"github.com/afritzler/garden-examiner/pkg"
+	"github.com/afritzler/garden-examiner/pkg/data"
+)
+
+const (
+	JSONPATH         = "jsonpath"
+	GO_TEMPLATE      = "go-template"
+	GO_TEMPLATE_FILE = "go-template-file"
+)
+
+// TemplateData is the data passed to go templates. The map keys are
+// the fields of the element manifest, the methods provide data derived
+// from the element.
+type TemplateData map[string]interface{}
+
+// template_element is the key for the element.
+const template_element = "\x00element"
+
+// template_element_ref keeps the element in the template data. It
+// offers no methods, so templates cannot access the element (e.g.
+// its kubeconfig) bypassing the redaction of secrets.
+type template_element_ref struct {
+	elem interface{}
+}
+
+func (this *template_element_ref) String() string {
+	return "<element>"
+}
+
+func NewTemplateData(e interface{}) (TemplateData, error) {
+	d, err := ElementData(e, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	delete(d, ATTR_FIELD)
+	d[template_element] = &template_element_ref{e}
+	return TemplateData(d), nil
+}
+
+func (this TemplateData) element() interface{} {
+	if r, ok := this[template_element].(*template_element_ref); ok {
+		return r.elem
+	}
+	return nil
+}
+
+// Attr provides a computed attribute (see ElementData).
+func (this TemplateData) Attr(name string) (interface{}, error) {
+	a, ok := element_attributes(this.element())[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown attribute '%s'", name)
+	}
+	return a(), nil
+}
+
+func (this TemplateData) Name() (interface{}, error) {
+	return this.Attr("name")
+}
+
+func (this TemplateData) Garden() (interface{}, error) {
+	return this.Attr("garden")
+}
+
+func (this TemplateData) State() (interface{}, error) {
+	return this.Attr("state")
+}
+
+func (this TemplateData) Nodes() (int, error) {
+	c, ok := this.element().(gube.Cluster)
+	if !ok {
+		return 0, fmt.Errorf("%s is no cluster", element_kind(this.element()))
+	}
+	return c.GetNodeCount()
+}
+
+func (this TemplateData) Seed() (TemplateData, error) {
+	s, ok := this.element().(gube.Shoot)
+	if !ok {
+		return nil, fmt.Errorf("%s has no seed", element_kind(this.element()))
+	}
+	seed, err := s.GetSeed()
+	if err != nil {
+		return nil, err
+	}
+	return NewTemplateData(seed)
+}
+
+func (this TemplateData) Project() (TemplateData, error) {
+	s, ok := this.element().(gube.Shoot)
+	if !ok {
+		return nil, fmt.Errorf("%s has no project", element_kind(this.element()))
+	}
+	p, err := s.GetProject()
+	if err != nil {
+		return nil, err
+	}
+	return NewTemplateData(p)
+}
+
+func (this TemplateData) Profile() (TemplateData, error) {
+	var p gube.Profile
+	var err error
+	switch s := this.element().(type) {
+	case gube.Shoot:
+		p, err = s.GetProfile()
+	case gube.Seed:
+		p, err = s.GetProfile()
+	default:
+		return nil, fmt.Errorf("%s has no profile", element_kind(this.element()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewTemplateData(p)
+}
+
+func (this TemplateData) IaaSInfo() (*TemplateIaaSInfo, error) {
+	s, ok := this.element().(gube.Shoot)
+	if !ok {
+		return nil, fmt.Errorf("%s has no iaas info", element_kind(this.element()))
+	}
+	info, err := s.GetIaaSInfo()
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateIaaSInfo{info}, nil
+}
+
+// TemplateIaaSInfo offers the iaas info of a shoot to templates
+// without access to the iaas specific credentials.
+type TemplateIaaSInfo struct {
+	info gube.IaaSInfo
+}
+
+func (this *TemplateIaaSInfo) GetKind() string {
+	return this.info.GetKind()
+}
+
+func (this *TemplateIaaSInfo) GetRegion() string {
+	return this.info.GetRegion()
+}
+
+func (this *TemplateIaaSInfo) GetKeyInfo() string {
+	return this.info.GetKeyInfo()
+}
+
+func element_kind(e interface{}) string {
+	switch e.(type) {
+	case gube.Shoot:
+		return "shoot"
+	case gube.Seed:
+		return "seed"
+	case gube.Profile:
+		return "profile"
+	case gube.Project:
+		return "project"
+	case gube.GardenConfig:
+		return "garden"
+	}
+	return fmt.Sprintf("%T", e)
+}
+
+var template_funcs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(sep string, v []interface{}) string {
+		s := make([]string, len(v))
+		for i, e := range v {
+			s[i] = util.JSONValueString(e)
+		}
+		return strings.Join(s, sep)
+	},
+}
+
+////////////////////////////////////////////////////////////////////////////
+
+// TemplateOutput prints the result of a template for every element.
+type TemplateOutput struct {
+	ElementOutput
+}
+
+var _ Output = &TemplateOutput{}
+
+type render_function func(e interface{}) (string, error)
+
+func NewTemplateOutput(ctx *context.Context, render render_function) *TemplateOutput {
+	return (&TemplateOutput{}).new(ctx, render)
+}
+
+func (this *TemplateOutput) new(ctx *context.Context, render render_function) *TemplateOutput {
+	mapper := func(e interface{}) (interface{}, error) {
+		return render(e)
+	}
+	timeout := func(e interface{}) interface{} {
+		return fmt.Sprintf("<timeout %s>\n", element_display_name(ctx, e))
+	}
+	this.ElementOutput.new(data.Chain().WithPool(ctx.NewPool(TargetKey)).
+		WithTimeout(ctx.TaskTimeout, timeout).MapWithError(mapper))
+	return this
+}
+
+func (this *TemplateOutput) Out(ctx *context.Context) error {
+	i := this.Elems.Iterator()
+	for i.HasNext() {
+		fmt.Print(i.Next().(string))
+	}
+	return this.Elems.Error()
+}
+
+// JSONPathOutputFactory provides an output for a kubectl like jsonpath
+// template evaluated on the element data (see ElementData).
+func JSONPathOutputFactory(opts *cmdint.Options) Output {
+	arg := OutputArgument(opts)
+	tmpl, err := util.ParseJSONPathTemplate(arg)
+	if err != nil {
+		return NewErrorOutput(err)
+	}
+	selected, all := referenced_attributes(arg)
+	if all {
+		selected = nil
+	}
+	return NewTemplateOutput(context.Get(opts), func(e interface{}) (string, error) {
+		d, err := ElementData(e, selected)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, d)
+		return buf.String(), err
+	})
+}
+
+// GoTemplateOutputFactory provides an output for a go template
+// executed on the TemplateData of the elements.
+func GoTemplateOutputFactory(opts *cmdint.Options) Output {
+	return go_template_output(opts, OutputArgument(opts))
+}
+
+func GoTemplateFileOutputFactory(opts *cmdint.Options) Output {
+	path := OutputArgument(opts)
+	if path == "" {
+		return NewErrorOutput(fmt.Errorf("template file required"))
+	}
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return NewErrorOutput(fmt.Errorf("cannot read template file: %s", err))
+	}
+	return go_template_output(opts, string(text))
+}
+
+func go_template_output(opts *cmdint.Options, text string) Output {
+	tmpl, err := template.New("output").Funcs(template_funcs).Parse(text)
+	if err != nil {
+		return NewErrorOutput(fmt.Errorf("invalid template: %s", err))
+	}
+	return NewTemplateOutput(context.Get(opts), func(e interface{}) (string, error) {
+		d, err := NewTemplateData(e)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, d)
+		return buf.String(), err
+	})
+}
@@ -1,37 +1,47 @@
 package profile
 
 import (
-	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
+	"github.com/afritzler/garden-examiner/pkg/data"
 )
 
 func init() {
 	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "describe", describe).CmdDescription(
 		"describe profile(s)",
+		"the description can be printed as text (default), yaml or json,",
+		"json always prints a list of descriptions",
 	).
-		CmdArgDescription("[<profile>]"))
+		CmdArgDescription("[<profile>]")).
+		ArgOption(constants.O_OUTPUT).Short('o').ArgDescription("text|yaml|json|JSON")
 }
 
 func describe(opts *cmdint.Options) error {
-	return cmdline.ExecuteOutput(opts, NewDescribeOutput(), TypeHandler)
+	mode, err := output.DescribeMode(opts)
+	if err != nil {
+		return err
+	}
+	return cmdline.ExecuteOutput(opts, NewDescribeOutput(mode), TypeHandler)
 }
 
 /////////////////////////////////////////////////////////////////////////////
 
 type describe_output struct {
 	*output.ElementOutput
+	mode string
 }
 
-func NewDescribeOutput() *describe_output {
-	o := &describe_output{}
+func NewDescribeOutput(mode string) *describe_output {
+	o := &describe_output{mode: mode}
 	o.ElementOutput = output.NewElementOutput(nil)
 	return o
 }
@@ -42,24 +52,33 @@ func (this *describe_output) Out(ctx *context.Context) error {
 	if err != nil {
 		return err
 	}
+	printer := output.NewDescriptionPrinter(this.mode)
 	i := this.Elems.Iterator()
 	for i.HasNext() {
-		fmt.Printf("---\n")
 		out.Describe(i.Next().(gube.Profile))
+		if err := printer.Print(out.AttributeSet); err != nil {
+			return err
+		}
 	}
-	return nil
+	return printer.Close()
 }
 
 type Output struct {
-	shoots map[gube.ShootName]gube.Shoot
-	seeds  map[string]gube.Seed
+	profile_shoots      map[interface{}]int
+	seed_profile_shoots map[interface{}]int
+	seeds               map[string]gube.Seed
 	*util.AttributeSet
 }
 
+type seed_profile struct {
+	seed    string
+	profile string
+}
+
 func NewOutput(g gube.Garden) (*Output, error) {
 	var err error
 	o := &Output{}
-	o.shoots, err = g.GetShoots()
+	shoots, err := g.GetShoots()
 	if err != nil {
 		return nil, err
 	}
@@ -67,53 +86,56 @@ func NewOutput(g gube.Garden) (*Output, error) {
 	if err != nil {
 		return nil, err
 	}
+	elems := data.IndexedSliceAccess{}
+	for _, s := range shoots {
+		elems = append(elems, s)
+	}
+	o.profile_shoots = data.CountBy(elems, func(e interface{}) interface{} {
+		return e.(gube.Shoot).GetProfileName()
+	})
+	o.seed_profile_shoots = data.CountBy(elems, func(e interface{}) interface{} {
+		s := e.(gube.Shoot)
+		return seed_profile{s.GetSeedName(), s.GetProfileName()}
+	})
 	o.AttributeSet = util.NewAttributeSet()
 	return o, nil
 }
 
 func (this *Output) CountProfileShoots(name string) int {
-	cnt := 0
-	for _, s := range this.shoots {
-		if s.GetProfileName() == name {
-			cnt++
-		}
-	}
-	return cnt
+	return this.profile_shoots[name]
 }
 
 func (this *Output) CountSeedProfileShoots(seed string, name string) int {
-	cnt := 0
-	for _, s := range this.shoots {
-		if s.GetSeedName() == seed && s.GetProfileName() == name {
-			cnt++
-		}
-	}
-	return cnt
+	return this.seed_profile_shoots[seed_profile{seed, name}]
 }
 
+// Describe describes a profile in the attribute set of the output.
+// Seeds with shoots of the profile, but another profile are not assigned.
 func (this *Output) Describe(p gube.Profile) error {
 	this.ResetAttributes()
 	this.Attribute("Profile", p.GetName())
 	this.Attribute("Infrastructure", p.GetInfrastructure())
-	this.Attribute("Total Number of Shoots", strconv.Itoa(this.CountProfileShoots(p.GetName())))
-	table := [][]string{[]string{"Seed", "Infra", "Region", "-Shoots"}}
+	this.AttributeValue("Total Number of Shoots", this.CountProfileShoots(p.GetName()))
+	names := []string{}
+	for n := range this.seeds {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	table := [][]string{}
 	used := 0
-	for _, s := range this.seeds {
+	for _, n := range names {
+		s := this.seeds[n]
 		cnt := this.CountSeedProfileShoots(s.GetName(), p.GetName())
 		if cnt > 0 || s.GetProfileName() == p.GetName() {
-			flag := ""
-			if s.GetProfileName() != p.GetName() {
-				flag = "*"
-			} else {
+			assigned := "no"
+			if s.GetProfileName() == p.GetName() {
+				assigned = "yes"
 				used++
 			}
-			table = append(table, []string{flag + s.GetName(), s.GetInfrastructure(), s.GetRegion(), strconv.Itoa(cnt)})
+			table = append(table, []string{s.GetName(), s.GetInfrastructure(), s.GetRegion(), assigned, strconv.Itoa(cnt)})
 		}
 	}
-	this.Attribute("Number of assigned Seeds", strconv.Itoa(used))
-	this.PrintAttributes()
-	if len(table) > 1 {
-		util.FormatTable("  ", table)
-	}
+	this.AttributeValue("Number of assigned Seeds", used)
+	this.Table("Seeds", []string{"Seed", "Infra", "Region", "Assigned", "-Shoots"}, table)
 	return nil
 }
@@ -11,9 +11,10 @@ import (
 )
 
 func init() {
-	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).CmdDescription("get profile(s)").
-		CmdArgDescription("[<profile>]").Mixed()).
-		ArgOption(constants.O_OUTPUT).Short('o')
+	filters.AddOptions(cmdline.AddWatchOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).CmdDescription("get profile(s)").
+		CmdArgDescription("[<profile>]").Mixed())).
+		ArgOption(constants.O_OUTPUT).Short('o').
+		FlagOption(constants.O_NOHEADERS).Description("omit header in csv and tsv output")
 }
 
 func get(opts *cmdint.Options) error {
@@ -26,7 +26,7 @@ func GetCmdTab() cmdint.ConfigurableCmdTab {
 
 /////////////////////////////////////////////////////////////////////////////
 
-var filters *util.Filters = util.NewFilters()
+var filters *util.Filters = util.NewFilters().Add(&cmdline.SelectorFilter{})
 
 /////////////////////////////////////////////////////////////////////////////
 
@@ -41,12 +41,24 @@ func (this *_TypeHandler) GetDefault(opts *cmdint.Options) *string {
 }
 
 func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]interface{}, error) {
-	elems, err := ctx.Garden.GetProfiles()
+	list, err := cmdline.ListOptions(opts, true)
+	if err != nil {
+		return nil, err
+	}
+	var elems map[string]gube.Profile
+	if list != nil {
+		elems, err = ctx.Garden.ListProfiles(*list)
+	} else {
+		elems, err = ctx.Garden.GetProfiles()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	this.data = elems
+	// a selection cannot be used to lookup elements by name
+	if list == nil {
+		this.data = elems
+	}
 	a := make([]interface{}, len(elems))
 	i := 0
 	for _, v := range elems {
@@ -56,6 +68,10 @@ func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]
 	return a, nil
 }
 
+func (this *_TypeHandler) Reset() {
+	this.data = nil
+}
+
 func (this *_TypeHandler) GetFilter() util.Filter {
 	return filters
 }
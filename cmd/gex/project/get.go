@@ -1,21 +1,32 @@
 package project
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/pkg"
 	"github.com/afritzler/garden-examiner/pkg/data"
 )
 
 func init() {
-	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).
-		CmdDescription("get projects(s)").
-		CmdArgDescription("[<project>]").Mixed()).
+	filters.AddOptions(cmdline.AddWatchOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).
+		CmdDescription("get projects(s)",
+			"supported output modes are:",
+			"- wide  namespace, creation, creator, shoot states,",
+			"        infrastructures and quotas",
+		).
+		CmdArgDescription("[<project>]").Mixed())).
 		ArgOption(constants.O_OUTPUT).Short('o').
-		ArgOption(constants.O_SORT).Array()
+		ArgOption(constants.O_SORT).Array().ArgDescription("[-]<column>{,[-]<column>}").Description("sort by columns, - for descending order").
+		FlagOption(constants.O_NOHEADERS).Description("omit header in csv and tsv output")
 }
 
 func get(opts *cmdint.Options) error {
@@ -24,14 +35,93 @@ func get(opts *cmdint.Options) error {
 
 /////////////////////////////////////////////////////////////////////////////
 
-var get_outputs = output.NewOutputs(get_regular)
+var get_outputs = output.NewOutputs(get_regular, output.Outputs{
+	"wide": get_wide,
+}).AddElementDataOutputs().AddTableFormats()
 
 func get_regular(opts *cmdint.Options) output.Output {
 	return output.NewProcessingTableOutput(opts, data.Chain().Map(map_get_regular_output),
-		"SEED", "INFRA", "REGION", "PROFILE", "SHOOT", "STATE", "ERROR")
+		"PROJECT", "NAMESPACE")
+}
+
+func get_wide(opts *cmdint.Options) output.Output {
+	ctx := context.Get(opts)
+	return output.NewProcessingTableOutput(opts, data.Chain().WithPool(ctx.NewPool(output.TargetKey)).
+		WithTimeout(ctx.TaskTimeout, map_get_wide_timeout).Map(map_get_wide_output),
+		"PROJECT", "NAMESPACE", "CREATED:time", "CREATOR", "-SHOOTS:number",
+		"-SUCCEEDED:number", "-FAILED:number", "-PROCESSING:number", "-PROBLEM:number", "-OTHER:number", "INFRA", "QUOTA").
+		WithWidths(12, 16, 20, 16, 6, 9, 6, 10, 7, 5, 12)
 }
 
 func map_get_regular_output(e interface{}) interface{} {
 	p := e.(gube.Project)
 	return []string{p.GetName(), p.GetNamespace()}
 }
+
+func map_get_wide_timeout(e interface{}) interface{} {
+	p := e.(gube.Project)
+	return []string{p.GetName(), p.GetNamespace(), created(p), p.GetCreator(), "?", "?", "?", "?", "?", "?", "timeout", "timeout"}
+}
+
+func map_get_wide_output(e interface{}) interface{} {
+	p := e.(gube.Project)
+	shoots := "unknown"
+	states := []string{"?", "?", "?", "?", "?"}
+	infras := "unknown"
+	quotas := "unknown"
+
+	list, err := p.Garden().GetShoots()
+	if err == nil {
+		s := summarize_shoots(p, list)
+		shoots = fmt.Sprintf("%d", s.count)
+		other := s.count
+		for i, n := range summary_states {
+			states[i] = fmt.Sprintf("%d", s.states[n])
+			other -= s.states[n]
+		}
+		states[len(summary_states)] = fmt.Sprintf("%d", other)
+		infras = strings.Join(s.infras, ",")
+	}
+	q, err := p.GetQuotas()
+	if err == nil {
+		quotas = strings.Join(q, ",")
+	}
+	return append(append([]string{p.GetName(), p.GetNamespace(), created(p), p.GetCreator(), shoots},
+		states...), infras, quotas)
+}
+
+func created(p gube.Project) string {
+	t := p.GetCreationTimestamp()
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// summary_states are the shoot states counted separately for a project,
+// shoots in other states (e.g. unknown or Error) are counted as other.
+var summary_states = []string{"Succeeded", "Failed", "Processing", "Problem"}
+
+type shoot_summary struct {
+	count  int
+	states map[string]int
+	infras []string
+}
+
+func summarize_shoots(p gube.Project, shoots map[gube.ShootName]gube.Shoot) *shoot_summary {
+	s := &shoot_summary{states: map[string]int{}, infras: []string{}}
+	infras := map[string]bool{}
+	for n, shoot := range shoots {
+		if n.GetProjectName() != p.GetName() {
+			continue
+		}
+		s.count++
+		s.states[shoot.GetState()]++
+		if i := shoot.GetInfrastructure(); !infras[i] {
+			infras[i] = true
+			s.infras = append(s.infras, i)
+		}
+	}
+	sort.Strings(s.infras)
+	return s
+}
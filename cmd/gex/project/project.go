@@ -28,7 +28,7 @@ func GetCmdTab() cmdint.ConfigurableCmdTab {
 
 /////////////////////////////////////////////////////////////////////////////
 
-var filters *util.Filters = util.NewFilters()
+var filters *util.Filters = util.NewFilters().Add(&cmdline.SelectorFilter{NoManifest: true})
 
 /////////////////////////////////////////////////////////////////////////////
 
@@ -44,12 +44,24 @@ func (this *_TypeHandler) GetDefault(opts *cmdint.Options) *string {
 }
 
 func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]interface{}, error) {
-	elems, err := ctx.Garden.GetProjects()
+	list, err := cmdline.ListOptions(opts, false)
+	if err != nil {
+		return nil, err
+	}
+	var elems map[string]gube.Project
+	if list != nil {
+		elems, err = ctx.Garden.ListProjects(*list)
+	} else {
+		elems, err = ctx.Garden.GetProjects()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	this.data = elems
+	// a selection cannot be used to lookup elements by name
+	if list == nil {
+		this.data = elems
+	}
 	a := make([]interface{}, len(elems))
 	i := 0
 	for _, v := range elems {
@@ -59,6 +71,10 @@ func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]
 	return a, nil
 }
 
+func (this *_TypeHandler) Reset() {
+	this.data = nil
+}
+
 func (this *_TypeHandler) GetFilter() util.Filter {
 	return filters
 }
@@ -1,38 +1,45 @@
 package seed
 
 import (
-	"fmt"
-	"strconv"
-
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/cmd/gex/shoot"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
+	"github.com/afritzler/garden-examiner/pkg/data"
 )
 
 func init() {
 	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "describe", describe).CmdDescription(
 		"describe seed(s)",
+		"the description can be printed as text (default), yaml or json,",
+		"json always prints a list of descriptions",
 	).
-		CmdArgDescription("[<seed>]"))
+		CmdArgDescription("[<seed>]")).
+		ArgOption(constants.O_OUTPUT).Short('o').ArgDescription("text|yaml|json|JSON")
 }
 
 func describe(opts *cmdint.Options) error {
-	return cmdline.ExecuteOutput(opts, NewDescribeOutput(), TypeHandler)
+	mode, err := output.DescribeMode(opts)
+	if err != nil {
+		return err
+	}
+	return cmdline.ExecuteOutput(opts, NewDescribeOutput(mode), TypeHandler)
 }
 
 /////////////////////////////////////////////////////////////////////////////
 
 type describe_output struct {
 	*output.ElementOutput
+	mode string
 }
 
-func NewDescribeOutput() *describe_output {
-	o := &describe_output{}
+func NewDescribeOutput(mode string) *describe_output {
+	o := &describe_output{mode: mode}
 	o.ElementOutput = output.NewElementOutput(nil)
 	return o
 }
@@ -40,30 +47,46 @@ func NewDescribeOutput() *describe_output {
 type ShootCount func(name string) int
 
 func (this *describe_output) Out(ctx *context.Context) error {
-	shoots, err := ctx.GetShoots()
-	f := func(name string) int {
-		cnt := 0
-		for _, s := range shoots {
-			if s.GetSeedName() == name {
-				cnt++
+	// shoots are counted per garden, seeds may belong to other
+	// gardens than the selected one
+	counts := map[string]map[interface{}]int{}
+	count := func(g gube.Garden) (ShootCount, error) {
+		c, ok := counts[g.GetName()]
+		if !ok {
+			shoots, err := g.GetShoots()
+			if err != nil {
+				return nil, err
+			}
+			elems := data.IndexedSliceAccess{}
+			for _, s := range shoots {
+				elems = append(elems, s)
 			}
+			c = data.CountBy(elems, func(e interface{}) interface{} {
+				return e.(gube.Shoot).GetSeedName()
+			})
+			counts[g.GetName()] = c
 		}
-		return cnt
-	}
-	if err != nil {
-		return err
+		return func(name string) int { return c[name] }, nil
 	}
+	printer := output.NewDescriptionPrinter(this.mode)
 	i := this.Elems.Iterator()
 	for i.HasNext() {
-		fmt.Printf("---\n")
-		Describe(i.Next().(gube.Seed), f)
+		seed := i.Next().(gube.Seed)
+		f, err := count(seed.Garden())
+		if err != nil {
+			return err
+		}
+		attrs := util.NewAttributeSet()
+		Describe(seed, f, attrs)
+		if err := printer.Print(attrs); err != nil {
+			return err
+		}
 	}
-	return nil
+	return printer.Close()
 }
 
-func Describe(s gube.Seed, shoot_count ShootCount) error {
-
-	attrs := util.NewAttributeSet()
+// Describe adds the description of a seed to an attribute set.
+func Describe(s gube.Seed, shoot_count ShootCount, attrs *util.AttributeSet) error {
 	attrs.Attribute("Seed", s.GetName())
 	cfg, err := s.GetClientConfig()
 	if err == nil {
@@ -71,31 +94,29 @@ func Describe(s gube.Seed, shoot_count ShootCount) error {
 	} else {
 		attrs.Attribute("API Server", "unknown")
 	}
-	attrs.Attributef("Profile", "%s (%s)", s.GetProfileName(), s.GetInfrastructure())
+	attrs.Attribute("Profile", s.GetProfileName())
+	attrs.Attribute("Infrastructure", s.GetInfrastructure())
 	attrs.Attribute("Region", s.GetRegion())
-	cnt := "unknown"
 	c, err := s.GetNodeCount()
 	if err == nil {
-		cnt = fmt.Sprintf("%d", c)
+		attrs.AttributeValue("Number of Nodes", c)
+	} else {
+		attrs.Attributef("Number of Nodes Error", "%s", err)
 	}
-	attrs.Attribute("Number of Nodes", cnt)
-
-	cnt = "unknown"
 	c, err = s.GetPodCount()
 	if err == nil {
-		cnt = fmt.Sprintf("%d", c)
+		attrs.AttributeValue("Number of Pods", c)
+	} else {
+		attrs.Attributef("Number of Pods Error", "%s", err)
 	}
-	attrs.Attribute("Number of Pods", cnt)
-
-	attrs.Attribute("Number of Shoots", strconv.Itoa(shoot_count(s.GetName())))
+	attrs.AttributeValue("Number of Shoots", shoot_count(s.GetName()))
 	if s.GetShootName() != nil {
 		sh, err := s.AsShoot()
 		if err != nil {
-			fmt.Printf("Seed is shooted, but cannot get shoot: %s\n", err)
+			attrs.Attributef("Shoot", "seed is shooted, but cannot get shoot: %s", err)
 		} else {
-			shoot.Describe(sh, attrs)
+			shoot.Describe(sh, attrs.Section("Shoot"))
 		}
 	}
-	attrs.PrintAttributes()
 	return nil
 }
@@ -0,0 +1,24 @@
+package seed
+
+import (
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/output"
+)
+
+func init() {
+	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "exec", exec).Raw().
+		CmdDescription("run command with seed credentials",
+			"The command is run with KUBECONFIG set to a temporary",
+			"kubeconfig file of the seed, which is removed afterwards.",
+			"Additionally GEX_GARDEN, GEX_PROJECT, GEX_SHOOT and",
+			"GEX_SEED describe the selected element.",
+		).
+		CmdArgDescription("[--seed <seed>] [--] <command> {<args>}").
+		ArgOption("seed"))
+}
+
+func exec(opts *cmdint.Options) error {
+	return cmdline.ExecuteOutputRaw("seed", opts, output.NewExecOutput(opts.Arguments), TypeHandler)
+}
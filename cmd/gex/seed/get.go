@@ -7,6 +7,7 @@ import (
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
@@ -14,11 +15,12 @@ import (
 )
 
 func init() {
-	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).
+	filters.AddOptions(cmdline.AddWatchOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).
 		CmdDescription("get seed(s)").
-		CmdArgDescription("[<seed>]").Mixed()).
+		CmdArgDescription("[[<garden>:]<seed>]").Mixed())).
 		ArgOption(constants.O_OUTPUT).Short('o').
-		ArgOption(constants.O_SORT).Array()
+		ArgOption(constants.O_SORT).Array().ArgDescription("[-]<column>{,[-]<column>}").Description("sort by columns, - for descending order").
+		FlagOption(constants.O_NOHEADERS).Description("omit header in csv and tsv output")
 }
 
 func get(opts *cmdint.Options) error {
@@ -32,12 +34,17 @@ var get_outputs = output.NewOutputs(get_regular, output.Outputs{
 }).AddManifestOutputs()
 
 func get_regular(opts *cmdint.Options) output.Output {
-	return output.NewProcessingTableOutput(opts, data.Chain().Map(map_get_regular_output),
-		"SEED", "INFRA", "REGION", "PROFILE", "SHOOT", "STATE", "ERROR")
+	return output.NewProcessingTableOutput(opts, data.Chain().Map(map_get_regular_output(context.Get(opts))),
+		"SEED", "INFRA", "REGION", "PROFILE", "SHOOT", "STATE:state", "ERROR")
 }
 
-func map_get_regular_output(e interface{}) interface{} {
-	s := e.(gube.Seed)
+func map_get_regular_output(ctx *context.Context) data.MappingFunction {
+	return func(e interface{}) interface{} {
+		return get_regular_output(ctx, e.(gube.Seed))
+	}
+}
+
+func get_regular_output(ctx *context.Context, s gube.Seed) interface{} {
 	c := s.GetCloud()
 	p, err := s.Garden().GetProfile(c.Profile)
 	i := "unknown"
@@ -59,5 +66,5 @@ func map_get_regular_output(e interface{}) interface{} {
 
 		}
 	}
-	return []string{s.GetName(), i, c.Region, c.Profile, shoot, state, util.Oneline(msg, 90)}
+	return []string{ctx.DisplayName(s.Garden(), s.GetName()), i, c.Region, c.Profile, shoot, state, util.Oneline(msg, 90)}
 }
@@ -14,7 +14,7 @@ func init() {
 		CmdDescription("run iaas specific cmd for seed or control plane in seed").
 		CmdArgDescription("[--seed <seed>] [cp] {<iaas args/options>}").
 		FlagOption("cp").Short('c').ArgDescription("switch to control plane").
-		FlagOption(constants.O_EXPORT).Short('e').Description("set CLI environment").
+		FlagOption(constants.O_EXPORT).Short('e').Description("export CLI config files").
 		ArgOption("seed"))
 }
 
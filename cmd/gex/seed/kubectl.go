@@ -4,16 +4,25 @@ import (
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 )
 
 func init() {
 	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "kubectl", kubectl).Raw().
-		CmdDescription("run kubectl for seed").
-		CmdArgDescription("[<seed>] {<kubectl args/options>}").
+		CmdDescription("run kubectl for seed",
+			"With --all kubectl is run in parallel for all seeds matching",
+			"the filters. The output is prefixed with the seed name",
+			"and followed by a summary of the exit codes.",
+		).
+		CmdArgDescription("[--seed <seed>|--all] {<kubectl args/options>}").
+		FlagOption(constants.O_ALL).Description("run for all matching seeds").
 		ArgOption("seed"))
 }
 
 func kubectl(opts *cmdint.Options) error {
+	if opts.IsFlag(constants.O_ALL) {
+		return cmdline.ExecuteOutputAll(opts, output.NewKubectlFanoutOutput(opts.Arguments, nil), TypeHandler)
+	}
 	return cmdline.ExecuteOutputRaw("seed", opts, output.NewKubectlOutput(opts.Arguments, nil), TypeHandler)
 }
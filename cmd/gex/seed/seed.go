@@ -3,6 +3,7 @@ package seed
 import (
 	"fmt"
 
+	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
@@ -26,7 +27,7 @@ func GetCmdTab() cmdint.ConfigurableCmdTab {
 
 /////////////////////////////////////////////////////////////////////////////
 
-var filters *util.Filters = util.NewFilters()
+var filters *util.Filters = util.NewFilters().Add(&cmdline.SelectorFilter{})
 
 /////////////////////////////////////////////////////////////////////////////
 
@@ -41,12 +42,24 @@ func (this *_TypeHandler) GetDefault(opts *cmdint.Options) *string {
 }
 
 func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]interface{}, error) {
-	elems, err := ctx.Garden.GetSeeds()
+	list, err := cmdline.ListOptions(opts, true)
+	if err != nil {
+		return nil, err
+	}
+	var elems map[string]gube.Seed
+	if list != nil {
+		elems, err = ctx.Garden.ListSeeds(*list)
+	} else {
+		elems, err = ctx.Garden.GetSeeds()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	this.data = elems
+	// a selection cannot be used to lookup elements by name
+	if list == nil {
+		this.data = elems
+	}
 	a := make([]interface{}, len(elems))
 	i := 0
 	for _, v := range elems {
@@ -56,6 +69,10 @@ func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]
 	return a, nil
 }
 
+func (this *_TypeHandler) Reset() {
+	this.data = nil
+}
+
 func (this *_TypeHandler) GetFilter() util.Filter {
 	return filters
 }
@@ -69,8 +86,12 @@ func (this *_TypeHandler) MatchName(e interface{}, name string) (bool, error) {
 }
 
 func (this *_TypeHandler) Get(ctx *context.Context, name string) (interface{}, error) {
-	if this.data == nil {
-		return ctx.Garden.GetSeed(name)
+	garden, g, name, err := ctx.ResolveName(name)
+	if err != nil {
+		return nil, err
+	}
+	if this.data == nil || !ctx.IsSelectedGarden(garden) {
+		return g.GetSeed(name)
 	}
 	s, ok := this.data[name]
 	if !ok {
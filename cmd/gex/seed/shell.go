@@ -12,9 +12,13 @@ func init() {
 	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "shell", cmd_shell).
 		CmdDescription("run shell on node").CmdArgDescription("[<seed>] <node>")).
 		ArgOption(constants.O_NODE).Short('n').ArgDescription("<name>").Description("node name").
-		ArgOption(constants.O_POD).Short('p').ArgDescription("<name>").Description("pod name")
+		ArgOption(constants.O_POD).Short('p').ArgDescription("<name>").Description("pod name").
+		FlagOption(constants.O_REAP).Description("delete abandoned shell pods")
 }
 
 func cmd_shell(opts *cmdint.Options) error {
+	if opts.IsFlag(constants.O_REAP) {
+		return cmdline.ExecuteOutput(opts, output.NewShellReapOutput(nil), TypeHandler)
+	}
 	return cmdline.ExecuteOutput(opts, output.NewShellOutput(opts.GetOptionValue(constants.O_NODE), opts.GetOptionValue(constants.O_POD), nil), TypeHandler)
 }
@@ -0,0 +1,39 @@
+package shoot
+
+import (
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/output"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+)
+
+func init() {
+	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "debug", debug).Raw().
+		CmdDescription("run debug shell for a pod",
+			"An ephemeral debug container is added to the given pod using",
+			"kubectl debug. The shell shares the network of the pod and the",
+			"process namespace of the selected container (default is the",
+			"first container). kubectl 1.20 or later and a cluster with",
+			"ephemeral containers (kubernetes 1.23 or later) are required.",
+			"Ephemeral containers cannot be removed, the terminated debug",
+			"container stays in the pod spec until the pod is recreated.",
+		).
+		CmdArgDescription("[--shoot <shoot>] [cp] [<namespace>/]<pod>").
+		FlagOption("cp").Short('c').ArgDescription("switch to control plane").
+		ArgOption(constants.O_CONTAINER).ArgDescription("<name>").Description("container name").
+		ArgOption(constants.O_IMAGE).ArgDescription("<image>").Description("debug image").
+		ArgOption("shoot"))
+}
+
+func debug(opts *cmdint.Options) error {
+	var mapper output.ElementMapper = nil
+	if opts.IsFlag("cp") {
+		mapper = seed_mapper
+	}
+	out := output.NewDebugOutput(opts.Arguments,
+		util.StringValue(opts.GetOptionValue(constants.O_CONTAINER)),
+		util.StringValue(opts.GetOptionValue(constants.O_IMAGE)), mapper)
+	return cmdline.ExecuteOutputRaw("shoot", opts, out, TypeHandler)
+}
@@ -1,11 +1,12 @@
 package shoot
 
 import (
-	"fmt"
+	"sort"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/iaas"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
@@ -16,47 +17,58 @@ import (
 func init() {
 	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "describe", describe).CmdDescription(
 		"describe shoot(s)",
+		"the description can be printed as text (default), yaml or json,",
+		"json always prints a list of descriptions",
 	).
-		CmdArgDescription("[<shoot>]"))
+		CmdArgDescription("[<shoot>]")).
+		ArgOption(constants.O_OUTPUT).Short('o').ArgDescription("text|yaml|json|JSON")
 }
 
 func describe(opts *cmdint.Options) error {
-	return cmdline.ExecuteOutput(opts, NewDescribeOutput(), TypeHandler)
+	mode, err := output.DescribeMode(opts)
+	if err != nil {
+		return err
+	}
+	return cmdline.ExecuteOutput(opts, NewDescribeOutput(mode), TypeHandler)
 }
 
 /////////////////////////////////////////////////////////////////////////////
 
 type describe_output struct {
 	*output.ElementOutput
+	mode string
 }
 
-func NewDescribeOutput() *describe_output {
-	o := &describe_output{}
+func NewDescribeOutput(mode string) *describe_output {
+	o := &describe_output{mode: mode}
 	o.ElementOutput = output.NewElementOutput(nil)
 	return o
 }
 
 func (this *describe_output) Out(ctx *context.Context) error {
+	printer := output.NewDescriptionPrinter(this.mode)
 	i := this.Elems.Iterator()
 	for i.HasNext() {
-		fmt.Printf("---\n")
-		Describe(i.Next().(gube.Shoot), nil)
+		attrs := util.NewAttributeSet()
+		Describe(i.Next().(gube.Shoot), attrs)
+		if err := printer.Print(attrs); err != nil {
+			return err
+		}
 	}
-	return nil
+	return printer.Close()
 }
 
-func Describe(s gube.Shoot, add *util.AttributeSet) error {
-	attrs := add
-	if add == nil {
-		attrs = util.NewAttributeSet()
-	}
+// Describe adds the description of a shoot to an attribute set.
+func Describe(s gube.Shoot, attrs *util.AttributeSet) error {
 	attrs.Attribute("Shoot", s.GetName().GetName())
 	attrs.Attribute("Project", s.GetName().GetProjectName())
 	p, err := s.GetProject()
 	if err == nil {
 		attrs.Attribute("Namespace", p.GetNamespace())
 	}
-	attrs.Attributef("Profile", "%s (%s)", s.GetProfileName(), s.GetInfrastructure())
+	attrs.Attribute("Profile", s.GetProfileName())
+	attrs.Attribute("Infrastructure", s.GetInfrastructure())
+	attrs.Attribute("Seed", s.GetSeedName())
 	attrs.Attribute("Seed Namespace", s.GetNamespaceInSeed())
 	attrs.Attributef("API Server", "https://api.%s", s.GetDomainName())
 	host, err := s.GetIngressHostFromSeed("alertmanager")
@@ -71,43 +83,45 @@ func Describe(s gube.Shoot, add *util.AttributeSet) error {
 	if err == nil {
 		attrs.Attribute("Prometheus", "https://"+host)
 	}
+	auth := attrs.Section("Basic Auth")
 	user, pass, err := s.GetBasicAuth()
 	if err != nil {
-		attrs.Attributef("Basic Auth", "%s", err)
+		auth.Attributef("Error", "%s", err)
 	} else {
-		attrs.Attributef("Basic Auth", "%s (%s)", user, pass)
+		auth.Attribute("User", user)
+		auth.SecretAttribute("Password", pass)
 	}
-	cnt := "unknown"
 	c, err := s.GetNodeCount()
 	if err == nil {
-		cnt = fmt.Sprintf("%d", c)
+		attrs.AttributeValue("Number of Nodes", c)
+	} else {
+		attrs.Attributef("Number of Nodes Error", "%s", err)
 	}
-	attrs.Attribute("Number of Nodes", cnt)
-
-	cnt = "unknown"
 	c, err = s.GetPodCount()
 	if err == nil {
-		cnt = fmt.Sprintf("%d", c)
+		attrs.AttributeValue("Number of Pods", c)
+	} else {
+		attrs.Attributef("Number of Pods Error", "%s", err)
 	}
-	attrs.Attribute("Number of Pods", cnt)
 
-	attrs.Attribute("State", s.GetState())
+	attrs.StyledAttribute("State", s.GetState(), output.StyleState)
 	cond := s.GetConditionErrors()
 	if cond != nil {
-		for c, m := range cond {
-			attrs.Attribute("  "+c, m)
+		names := []string{}
+		for c := range cond {
+			names = append(names, c)
+		}
+		sort.Strings(names)
+		conditions := attrs.Section("Conditions")
+		for _, c := range names {
+			conditions.StyledAttribute(c, cond[c], output.StyleError)
 		}
-	} else {
 	}
-	//iaas, err := s.GetIaaSInfo()
 	iaas.Describe(s, attrs)
 
 	e := s.GetError()
 	if e != "" {
-		attrs.Attribute("Error", s.GetError())
-	}
-	if add == nil {
-		attrs.PrintAttributes()
+		attrs.StyledAttribute("Error", s.GetError(), output.StyleError)
 	}
 	return nil
 }
@@ -0,0 +1,24 @@
+package shoot
+
+import (
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/output"
+)
+
+func init() {
+	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "exec", exec).Raw().
+		CmdDescription("run command with shoot credentials",
+			"The command is run with KUBECONFIG set to a temporary",
+			"kubeconfig file of the shoot, which is removed afterwards.",
+			"Additionally GEX_GARDEN, GEX_PROJECT, GEX_SHOOT and",
+			"GEX_SEED describe the selected element.",
+		).
+		CmdArgDescription("[--shoot <shoot>] [--] <command> {<args>}").
+		ArgOption("shoot"))
+}
+
+func exec(opts *cmdint.Options) error {
+	return cmdline.ExecuteOutputRaw("shoot", opts, output.NewExecOutput(opts.Arguments), TypeHandler)
+}
@@ -2,11 +2,13 @@ package shoot
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
 	"github.com/afritzler/garden-examiner/pkg"
@@ -14,17 +16,32 @@ import (
 )
 
 func init() {
-	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).CmdDescription(
+	filters.AddOptions(cmdline.AddWatchOptions(cmdline.AddAsVerb(GetCmdTab(), "get", get).CmdDescription(
 		"get shoot(s)",
 		"supported output modes are:",
 		"- yaml|json|JSON  print manifest",
 		"- wide            additional info",
 		"- kubeconfig      print kube config",
 		"- error           show complete error message",
+		"- custom-columns=<header>[:<type>]:<jsonpath>,...",
+		"                  columns evaluated on the manifest, computed",
+		"                  attributes are found below .gex (e.g. .gex.state)",
+		"- custom-columns=<set>  column set from gex config",
+		"- jsonpath=<template>   kubectl like jsonpath template",
+		"- go-template=<template>|go-template-file=<file>",
+		"                  go template on the manifest, methods like .State,",
+		"                  .Seed, .Project or .IaaSInfo provide derived data",
+		"- csv|tsv|markdown|ndjson[=<table mode>]",
+		"                  render a table mode (default, wide, error or",
+		"                  custom-columns=...) for spreadsheets or wikis",
+		"with --watch the table is updated on shoot changes of the gardens of",
+		"the given shoots and periodically (see --watch-interval),",
+		"changed rows are highlighted",
 	).
-		CmdArgDescription("[<shoot>]").Mixed()).
+		CmdArgDescription("[[<garden>:]<shoot>]").Mixed())).
 		ArgOption(constants.O_OUTPUT).Short('o').
-		ArgOption(constants.O_SORT).Array()
+		ArgOption(constants.O_SORT).Array().ArgDescription("[-]<column>{,[-]<column>}").Description("sort by columns, - for descending order").
+		FlagOption(constants.O_NOHEADERS).Description("omit header in csv and tsv output")
 }
 
 func get(opts *cmdint.Options) error {
@@ -40,40 +57,70 @@ var get_outputs = output.NewOutputs(get_regular, output.Outputs{
 }).AddManifestOutputs()
 
 func get_regular(opts *cmdint.Options) output.Output {
-	return output.NewProcessingTableOutput(opts, data.Chain().Map(map_get_regular_output),
-		"SHOOT", "PROJECT", "INFRA", "PROFLE", "SEED", "STATE", "ERROR")
+	return output.NewProcessingTableOutput(opts, data.Chain().Map(map_get_regular_output(context.Get(opts))),
+		"SHOOT", "PROJECT", "INFRA", "PROFLE", "SEED", "STATE:state", "ERROR")
 }
 func get_wide(opts *cmdint.Options) output.Output {
-	return output.NewProcessingTableOutput(opts, data.Chain().Parallel(20).Map(map_get_wide_output),
-		"SHOOT", "PROJECT", "INFRA", "PROFILE", "SEED", "-NODES", "IAAS", "STATE", "ERROR")
+	ctx := context.Get(opts)
+	return output.NewProcessingTableOutput(opts, data.Chain().WithPool(ctx.NewPool(output.TargetKey)).
+		WithTimeout(ctx.TaskTimeout, map_get_wide_timeout(ctx)).Map(map_get_wide_output(ctx)),
+		"SHOOT", "PROJECT", "INFRA", "PROFILE", "SEED", "-NODES:number", "IAAS", "STATE:state", "ERROR").
+		WithWidths(12, 12, 9, 12, 16, 5, 9, 9)
 }
 func get_error(opts *cmdint.Options) output.Output {
-	return output.NewProcessingTableOutput(opts, data.Chain().Parallel(20).Map(map_get_error_output),
-		"SHOOT", "ERROR")
+	return output.NewProcessingTableOutput(opts, data.Chain().WithPool(context.Get(opts).NewPool(output.TargetKey)).Map(map_get_error_output),
+		"SHOOT", "ERROR").
+		WithWidths(12)
 }
 
 /////////////////////////////////////////////////////////////////////////////
 
-func map_get_regular_output(e interface{}) interface{} {
-	s := e.(gube.Shoot)
-	return []string{s.GetName().GetName(), s.GetName().GetProjectName(),
-		s.GetInfrastructure(), s.GetProfileName(), s.GetSeedName(), s.GetState(), util.Oneline(s.GetError(), 90)}
+func map_get_regular_output(ctx *context.Context) data.MappingFunction {
+	return func(e interface{}) interface{} {
+		s := e.(gube.Shoot)
+		return []string{s.GetName().GetName(), ctx.DisplayName(s.Garden(), s.GetName().GetProjectName()),
+			s.GetInfrastructure(), s.GetProfileName(), s.GetSeedName(), s.GetState(), util.Oneline(s.GetError(), 90)}
+	}
 }
 
-func map_get_wide_output(e interface{}) interface{} {
-	s := e.(gube.Shoot)
-	cnt := "unknown"
+func map_get_wide_output(ctx *context.Context) data.MappingFunction {
+	return func(e interface{}) interface{} {
+		return get_wide_output(ctx, e.(gube.Shoot))
+	}
+}
+
+func map_get_wide_timeout(ctx *context.Context) data.TimeoutFunction {
+	return func(e interface{}) interface{} {
+		s := e.(gube.Shoot)
+		return []string{s.GetName().GetName(), ctx.DisplayName(s.Garden(), s.GetName().GetProjectName()),
+			s.GetInfrastructure(), s.GetProfileName(), s.GetSeedName(), "?", "timeout", s.GetState(), "request timed out"}
+	}
+}
+
+// get_wide_output keeps shoots whose node count or iaas info cannot
+// be determined (e.g. hibernated shoots), the failing cells are marked
+// and the error is shown in the error column.
+func get_wide_output(ctx *context.Context, s gube.Shoot) interface{} {
+	errs := []string{}
+	cnt := "?"
 	c, err := s.GetNodeCount()
 	if err == nil {
 		cnt = fmt.Sprintf("%d", c)
+	} else {
+		errs = append(errs, fmt.Sprintf("cannot get node count: %s", err))
 	}
+	info := "error"
 	iaas, err := s.GetIaaSInfo()
-	info := "unknown"
 	if err == nil {
 		info = iaas.GetKeyInfo()
+	} else {
+		errs = append(errs, fmt.Sprintf("cannot get iaas info: %s", err))
+	}
+	if msg := s.GetError(); msg != "" {
+		errs = append([]string{msg}, errs...)
 	}
-	return []string{s.GetName().GetName(), s.GetName().GetProjectName(),
-		s.GetInfrastructure(), s.GetProfileName(), s.GetSeedName(), cnt, info, s.GetState(), util.Oneline(s.GetError(), 90)}
+	return []string{s.GetName().GetName(), ctx.DisplayName(s.Garden(), s.GetName().GetProjectName()),
+		s.GetInfrastructure(), s.GetProfileName(), s.GetSeedName(), cnt, info, s.GetState(), util.Oneline(strings.Join(errs, "; "), 90)}
 }
 
 func map_get_error_output(e interface{}) interface{} {
@@ -4,6 +4,7 @@ import (
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
 	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/output"
 	"github.com/afritzler/garden-examiner/pkg"
@@ -11,9 +12,14 @@ import (
 
 func init() {
 	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "kubectl", kubectl).Raw().
-		CmdDescription("run kubectl for shoot or control plane in seed").
-		CmdArgDescription("[--shoot <shoot>] [cp] {<kubectl args/options>}").
+		CmdDescription("run kubectl for shoot or control plane in seed",
+			"With --all kubectl is run in parallel for all shoots matching",
+			"the filters. The output is prefixed with the shoot name",
+			"and followed by a summary of the exit codes.",
+		).
+		CmdArgDescription("[--shoot <shoot>|--all] [cp] {<kubectl args/options>}").
 		FlagOption("cp").Short('c').ArgDescription("switch to control plane").
+		FlagOption(constants.O_ALL).Description("run for all matching shoots").
 		ArgOption("shoot"))
 }
 
@@ -29,5 +35,8 @@ func kubectl(opts *cmdint.Options) error {
 	if opts.IsFlag("cp") {
 		mapper = seed_kubectl_mapper
 	}
+	if opts.IsFlag(constants.O_ALL) {
+		return cmdline.ExecuteOutputAll(opts, output.NewKubectlFanoutOutput(opts.Arguments, mapper), TypeHandler)
+	}
 	return cmdline.ExecuteOutputRaw("shoot", opts, output.NewKubectlOutput(opts.Arguments, mapper), TypeHandler)
 }
@@ -15,7 +15,8 @@ func init() {
 		CmdDescription("run shell on node").CmdArgDescription("[<shoot>] <node>")).
 		ArgOption(constants.O_NODE).Short('n').ArgDescription("<name>").Description("node name").
 		ArgOption(constants.O_POD).Short('p').ArgDescription("<name>").Description("pod name").
-		FlagOption("cp").Short('c').ArgDescription("switch to control plane")
+		FlagOption("cp").Short('c').ArgDescription("switch to control plane").
+		FlagOption(constants.O_REAP).Description("delete abandoned shell pods")
 }
 
 func seed_mapper(ctx *context.Context, e interface{}) (interface{}, []string, error) {
@@ -33,5 +34,8 @@ func cmd_shell(opts *cmdint.Options) error {
 	if opts.IsFlag("cp") {
 		mapper = seed_mapper
 	}
+	if opts.IsFlag(constants.O_REAP) {
+		return cmdline.ExecuteOutput(opts, output.NewShellReapOutput(mapper), TypeHandler)
+	}
 	return cmdline.ExecuteOutput(opts, output.NewShellOutput(opts.GetOptionValue(constants.O_NODE), opts.GetOptionValue(constants.O_POD), mapper), TypeHandler)
 }
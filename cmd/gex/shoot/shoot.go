@@ -5,9 +5,11 @@ import (
 	"strings"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
+	"k8s.io/apimachinery/pkg/watch"
 
 	"github.com/afritzler/garden-examiner/pkg"
 
+	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
 	"github.com/afritzler/garden-examiner/cmd/gex/const"
 	"github.com/afritzler/garden-examiner/cmd/gex/context"
 	"github.com/afritzler/garden-examiner/cmd/gex/util"
@@ -31,7 +33,7 @@ func GetCmdTab() cmdint.ConfigurableCmdTab {
 
 /////////////////////////////////////////////////////////////////////////////
 
-var filters *util.Filters = util.NewFilters()
+var filters *util.Filters = util.NewFilters().Add(&cmdline.SelectorFilter{})
 
 /////////////////////////////////////////////////////////////////////////////
 
@@ -42,12 +44,24 @@ type _TypeHandler struct {
 var TypeHandler = &_TypeHandler{}
 
 func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]interface{}, error) {
-	elems, err := ctx.Garden.GetShoots()
+	list, err := cmdline.ListOptions(opts, true)
+	if err != nil {
+		return nil, err
+	}
+	var elems map[gube.ShootName]gube.Shoot
+	if list != nil {
+		elems, err = ctx.Garden.ListShoots(*list)
+	} else {
+		elems, err = ctx.Garden.GetShoots()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	this.data = elems
+	// a selection cannot be used to lookup elements by name
+	if list == nil {
+		this.data = elems
+	}
 	a := make([]interface{}, len(elems))
 	i := 0
 	for _, v := range elems {
@@ -57,6 +71,19 @@ func (this *_TypeHandler) GetAll(ctx *context.Context, opts *cmdint.Options) ([]
 	return a, nil
 }
 
+func (this *_TypeHandler) Reset() {
+	this.data = nil
+}
+
+// Watch provides the shoot change events of a garden.
+func (this *_TypeHandler) Watch(ctx *context.Context, garden string) (watch.Interface, error) {
+	g, err := ctx.GetGarden(garden)
+	if err != nil {
+		return nil, err
+	}
+	return g.WatchShoots()
+}
+
 func (this *_TypeHandler) GetFilter() util.Filter {
 	return filters
 }
@@ -64,6 +91,9 @@ func (this *_TypeHandler) GetDefault(opts *cmdint.Options) *string {
 	return opts.LookupOptionValue(constants.O_SEL_SHOOT)
 }
 func (this *_TypeHandler) RequireScan(name string) bool {
+	if context.IsQualified(name) {
+		return false
+	}
 	i := strings.Index(name, "/")
 	return i < 0
 }
@@ -72,8 +102,24 @@ func (this *_TypeHandler) MatchName(e interface{}, name string) (bool, error) {
 	return s.GetName().GetName() == name, nil
 }
 func (this *_TypeHandler) Get(ctx *context.Context, name string) (interface{}, error) {
-	i := strings.Index(name, "/")
-	sn := gube.NewShootName(string(name[:i]), string(name[i+1:]))
+	garden, g, local, err := ctx.ResolveName(name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Index(local, "/") < 0 {
+		return this.lookup(g, garden, local)
+	}
+	if !ctx.IsSelectedGarden(garden) {
+		sn, err := gube.ParseShootName(local)
+		if err != nil {
+			return nil, err
+		}
+		return g.GetShoot(sn)
+	}
+	sn, err := gube.ParseShootName(local)
+	if err != nil {
+		return nil, err
+	}
 	if this.data == nil {
 		//fmt.Printf("use garden %p\n", ctx.Garden)
 		return ctx.Garden.GetShoot(sn)
@@ -84,3 +130,24 @@ func (this *_TypeHandler) Get(ctx *context.Context, name string) (interface{}, e
 	}
 	return s, nil
 }
+
+// lookup looks up a shoot by its qualified name without project.
+func (this *_TypeHandler) lookup(g gube.Garden, garden, name string) (interface{}, error) {
+	shoots, err := g.GetShoots()
+	if err != nil {
+		return nil, err
+	}
+	var found gube.Shoot
+	for n, s := range shoots {
+		if n.GetName() == name {
+			if found != nil {
+				return nil, fmt.Errorf("shoot name '%s' is not unique in garden '%s'", name, garden)
+			}
+			found = s
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("shoot '%s' not found in garden '%s'", name, garden)
+	}
+	return found, nil
+}
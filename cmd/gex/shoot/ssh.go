@@ -0,0 +1,27 @@
+package shoot
+
+import (
+	"github.com/mandelsoft/cmdint/pkg/cmdint"
+
+	"github.com/afritzler/garden-examiner/cmd/gex/cmdline"
+	"github.com/afritzler/garden-examiner/cmd/gex/const"
+	"github.com/afritzler/garden-examiner/cmd/gex/output"
+	"github.com/afritzler/garden-examiner/cmd/gex/util"
+)
+
+func init() {
+	filters.AddOptions(cmdline.AddAsVerb(GetCmdTab(), "ssh", ssh).Raw().
+		CmdDescription("run ssh session on node",
+			"The shoot's ssh key pair is taken from the seed and the",
+			"node is reached via its internal ip through a temporary",
+			"jump pod in the shoot cluster.",
+		).
+		CmdArgDescription("[--shoot <shoot>] <node> {<command>}").
+		ArgOption(constants.O_USER).Short('u').ArgDescription("<name>").Description("ssh user name").
+		ArgOption("shoot"))
+}
+
+func ssh(opts *cmdint.Options) error {
+	out := output.NewSSHOutput(opts.Arguments, util.StringValue(opts.GetOptionValue(constants.O_USER)))
+	return cmdline.ExecuteOutputRaw("shoot", opts, out, TypeHandler)
+}
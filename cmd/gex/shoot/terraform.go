@@ -50,16 +50,19 @@ func terraform(opts *cmdint.Options) error {
 }
 
 func NewTerraformOutput(cm string, field string) output.Output {
-	return output.NewStringOutput(terraform_output_mapper(cm, field), "---")
+	return output.NewStringOutputWithError(terraform_output_mapper(cm, field), "---")
 }
 
-func terraform_output_mapper(job, data string) data.MappingFunction {
-	return func(e interface{}) interface{} {
+func terraform_output_mapper(job, data string) data.ErrorMappingFunction {
+	return func(e interface{}) (interface{}, error) {
 		s := e.(gube.Shoot)
 		result, err := s.GetTerraformJobData(job, data)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("cannot get terraform %s data of shoot '%s': %s", job, s.GetName(), err)
 		}
-		return result
+		if data == "state" {
+			return output.RedactTerraformState(result), nil
+		}
+		return output.RedactText(result), nil
 	}
 }
@@ -1,11 +1,41 @@
 package util
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	attr_value = iota
+	attr_section
+	attr_list
+	attr_table
+	attr_block
 )
 
+type attribute struct {
+	kind    int
+	name    string
+	value   interface{}
+	secret  bool
+	section *AttributeSet
+	list    []string
+	table   [][]string
+	text    func(gap string)
+	style   func(string) string
+}
+
+// AttributeSet is a structured document of named attributes, nested
+// sections, lists and tables. It can be printed as aligned text or
+// provided as ordered data for YAML or JSON. The keys of the data are
+// derived from the attribute names (e.g. "API Server" -> "apiServer").
 type AttributeSet struct {
-	attrs [][]string
+	attrs []*attribute
 }
 
 func NewAttributeSet() *AttributeSet {
@@ -15,17 +45,267 @@ func NewAttributeSet() *AttributeSet {
 }
 
 func (this *AttributeSet) ResetAttributes() {
-	this.attrs = [][]string{[]string{}}
+	this.attrs = []*attribute{}
+}
+
+func (this *AttributeSet) add(a *attribute) *attribute {
+	this.attrs = append(this.attrs, a)
+	return a
 }
 
 func (this *AttributeSet) Attribute(name, value string) {
-	this.attrs = append(this.attrs, []string{name + ":", value})
+	this.add(&attribute{kind: attr_value, name: name, value: value})
 }
 
 func (this *AttributeSet) Attributef(name, f string, args ...interface{}) {
-	this.attrs = append(this.attrs, []string{name + ":", fmt.Sprintf(f, args...)})
+	this.Attribute(name, fmt.Sprintf(f, args...))
+}
+
+// AttributeValue adds an attribute with a typed value, like a number,
+// which is kept for the structured output.
+func (this *AttributeSet) AttributeValue(name string, value interface{}) {
+	this.add(&attribute{kind: attr_value, name: name, value: value})
+}
+
+// StyledAttribute adds an attribute whose value is styled by the
+// given function for the text output.
+func (this *AttributeSet) StyledAttribute(name, value string, style func(string) string) {
+	this.add(&attribute{kind: attr_value, name: name, value: value, style: style})
+}
+
+// SecretAttribute adds an attribute whose value must be passed
+// through a redaction function (see MapSecrets) before being shown.
+func (this *AttributeSet) SecretAttribute(name, value string) {
+	this.add(&attribute{kind: attr_value, name: name, value: value, secret: true})
 }
 
+// Section adds a nested attribute set.
+func (this *AttributeSet) Section(name string) *AttributeSet {
+	return this.add(&attribute{kind: attr_section, name: name, section: NewAttributeSet()}).section
+}
+
+// List adds a list of values.
+func (this *AttributeSet) List(name string, values ...string) {
+	this.add(&attribute{kind: attr_list, name: name, list: values})
+}
+
+// Table adds a table. A leading - of a header field aligns the column
+// right in text output, for the structured output every row is
+// provided with the keys derived from the header fields.
+func (this *AttributeSet) Table(name string, header []string, rows [][]string) {
+	this.add(&attribute{kind: attr_table, name: name, table: append([][]string{header}, rows...)})
+}
+
+// Block adds a value with a dedicated text representation printed
+// by the given function.
+func (this *AttributeSet) Block(name string, value interface{}, text func(gap string)) {
+	this.add(&attribute{kind: attr_block, name: name, value: value, text: text})
+}
+
+func (this *AttributeSet) MapSecrets(mapper func(string) string) {
+	for _, a := range this.attrs {
+		switch {
+		case a.secret:
+			a.value = mapper(fmt.Sprint(a.value))
+		case a.section != nil:
+			a.section.MapSecrets(mapper)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////
+// text
+
 func (this *AttributeSet) PrintAttributes() {
-	FormatTable("", this.attrs)
+	this.print("")
+}
+
+// print prints the attributes as aligned table. Sections are indented
+// below their name, tables and blocks interrupt the alignment.
+func (this *AttributeSet) print(gap string) {
+	rows := [][]string{[]string{}}
+	this.rows(gap, &rows)
+	flush_rows(&rows)
+}
+
+func flush_rows(rows *[][]string) {
+	if len(*rows) > 1 {
+		FormatTable("", *rows)
+	}
+	*rows = [][]string{[]string{}}
+}
+
+func (this *AttributeSet) rows(gap string, rows *[][]string) {
+	for _, a := range this.attrs {
+		switch a.kind {
+		case attr_value:
+			v := fmt.Sprint(a.value)
+			if a.style != nil {
+				v = a.style(v)
+			}
+			*rows = append(*rows, []string{gap + a.name + ":", v})
+		case attr_section:
+			*rows = append(*rows, []string{gap + a.name + ":", ""})
+			a.section.rows(gap+"  ", rows)
+		case attr_list:
+			*rows = append(*rows, []string{gap + a.name + ":", strings.Join(a.list, ", ")})
+		case attr_table, attr_block:
+			flush_rows(rows)
+			fmt.Printf("%s%s:\n", gap, a.name)
+			if a.kind == attr_table {
+				if len(a.table) > 1 {
+					FormatTable(gap+"  ", copy_table(a.table))
+				}
+			} else {
+				a.text(gap + "  ")
+			}
+		}
+	}
+}
+
+func copy_table(table [][]string) [][]string {
+	result := make([][]string, len(table))
+	for i, r := range table {
+		result[i] = append([]string{}, r...)
+	}
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////
+// structured data
+
+type attribute_entry struct {
+	key   string
+	value interface{}
+}
+
+// attribute_data is an ordered map marshalled to JSON or YAML.
+type attribute_data []attribute_entry
+
+func (this attribute_data) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("{")
+	for i, e := range this {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		k, err := marshal_json(e.key, false)
+		if err != nil {
+			return nil, err
+		}
+		v, err := marshal_json(e.value, false)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteString(":")
+		buf.Write(v)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+func (this attribute_data) MarshalYAML() (interface{}, error) {
+	m := yaml.MapSlice{}
+	for _, e := range this {
+		m = append(m, yaml.MapItem{Key: e.key, Value: e.value})
+	}
+	return m, nil
+}
+
+// Data provides the attributes as ordered data suitable for
+// YAML or JSON marshalling.
+func (this *AttributeSet) Data() interface{} {
+	data := attribute_data{}
+	for _, a := range this.attrs {
+		var v interface{}
+		switch a.kind {
+		case attr_value, attr_block:
+			v = a.value
+		case attr_section:
+			v = a.section.Data()
+		case attr_list:
+			v = a.list
+		case attr_table:
+			keys := make([]string, len(a.table[0]))
+			for i, h := range a.table[0] {
+				keys[i] = AttributeKey(strings.TrimPrefix(h, "-"))
+			}
+			rows := []attribute_data{}
+			for _, r := range a.table[1:] {
+				row := attribute_data{}
+				for i, c := range r {
+					if i < len(keys) {
+						row = append(row, attribute_entry{keys[i], c})
+					}
+				}
+				rows = append(rows, row)
+			}
+			v = rows
+		}
+		data = append(data, attribute_entry{AttributeKey(a.name), v})
+	}
+	return data
+}
+
+func (this *AttributeSet) JSON(pretty bool) ([]byte, error) {
+	return marshal_json(this.Data(), pretty)
+}
+
+// MarshalJSON marshals data like the attribute sets, e.g. a list of
+// attribute set data (see Data).
+func MarshalJSON(v interface{}, pretty bool) ([]byte, error) {
+	return marshal_json(v, pretty)
+}
+
+// marshal_json marshals without escaping HTML characters
+// like the < and > of redacted values.
+func marshal_json(v interface{}, pretty bool) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func (this *AttributeSet) YAML() ([]byte, error) {
+	return yaml.Marshal(this.Data())
+}
+
+// AttributeKey derives a lower camel case key from an attribute name,
+// e.g. "API Server" -> "apiServer", "APIServerAvailable" -> "apiServerAvailable".
+func AttributeKey(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	key := ""
+	for i, w := range words {
+		r := []rune(w)
+		if i == 0 {
+			// lower the leading upper case run, but keep the start of
+			// a following capitalized word
+			n := 0
+			for n < len(r) && unicode.IsUpper(r[n]) {
+				n++
+			}
+			if n > 1 && n < len(r) {
+				n--
+			}
+			for j := 0; j < n; j++ {
+				r[j] = unicode.ToLower(r[j])
+			}
+		} else {
+			if strings.ToUpper(w) == w {
+				r = []rune(strings.ToLower(w))
+			}
+			r[0] = unicode.ToUpper(r[0])
+		}
+		key += string(r)
+	}
+	return key
 }
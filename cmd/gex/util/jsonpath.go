@@ -0,0 +1,524 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is a parsed JSONPath expression evaluated against generic
+// json data (maps, slices and values). It supports the subset used
+// by kubectl: fields (.a, ['a']), wildcards (.*, [*]), indices ([n],
+// negative from the end), recursive descent (..a) and filters
+// ([?(@.a=="x")] with == != < <= > >= or just existence).
+type JSONPath struct {
+	expr  string
+	steps []jsonpath_step
+}
+
+type jsonpath_step func(in []interface{}) ([]interface{}, error)
+
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	p := &JSONPath{expr: expr}
+	s := strings.TrimSpace(expr)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	s = strings.TrimPrefix(s, "$")
+	steps, err := parse_jsonpath_steps(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath '%s': %s", expr, err)
+	}
+	p.steps = steps
+	return p, nil
+}
+
+func (this *JSONPath) String() string {
+	return this.expr
+}
+
+// Eval provides all values matched by the expression.
+func (this *JSONPath) Eval(data interface{}) ([]interface{}, error) {
+	values := []interface{}{data}
+	var err error
+	for _, s := range this.steps {
+		values, err = s(values)
+		if err != nil {
+			return nil, fmt.Errorf("jsonpath '%s': %s", this.expr, err)
+		}
+	}
+	return values, nil
+}
+
+// JSONValueString formats a value found by a JSONPath expression.
+// Structured values are formatted as json.
+func JSONValueString(v interface{}) string {
+	switch r := v.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return r
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Sprintf("%v", r)
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// ToJSONData converts an object into generic json data.
+func ToJSONData(o interface{}) (interface{}, error) {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+////////////////////////////////////////////////////////////////////////////
+
+func parse_jsonpath_steps(s string) ([]jsonpath_step, error) {
+	steps := []jsonpath_step{}
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := jsonpath_name(s[2:])
+			if name == "" {
+				return nil, fmt.Errorf("field name expected after '..'")
+			}
+			steps = append(steps, jsonpath_descent(name))
+			s = rest
+		case s[0] == '.':
+			name, rest := jsonpath_name(s[1:])
+			s = rest
+			switch name {
+			case "":
+				// the root element
+			case "*":
+				steps = append(steps, jsonpath_wildcard)
+			default:
+				steps = append(steps, jsonpath_field(name))
+			}
+		case s[0] == '[':
+			end := jsonpath_bracket_end(s)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '['")
+			}
+			step, err := parse_jsonpath_bracket(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			s = s[end+1:]
+		default:
+			name, rest := jsonpath_name(s)
+			if name == "" {
+				return nil, fmt.Errorf("unexpected '%s'", s)
+			}
+			steps = append(steps, jsonpath_field(name))
+			s = rest
+		}
+	}
+	return steps, nil
+}
+
+func jsonpath_name(s string) (string, string) {
+	i := strings.IndexAny(s, ".[")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// jsonpath_bracket_end finds the closing bracket, respecting quotes
+// and nested brackets.
+func jsonpath_bracket_end(s string) int {
+	level := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			level++
+		case c == ']':
+			level--
+			if level == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parse_jsonpath_bracket(s string) (jsonpath_step, error) {
+	switch {
+	case s == "*":
+		return jsonpath_wildcard, nil
+	case strings.HasPrefix(s, "?(") && strings.HasSuffix(s, ")"):
+		return parse_jsonpath_filter(strings.TrimSpace(s[2 : len(s)-1]))
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return jsonpath_field(s[1 : len(s)-1]), nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index '%s'", s)
+	}
+	return jsonpath_index(n), nil
+}
+
+func jsonpath_field(name string) jsonpath_step {
+	return func(in []interface{}) ([]interface{}, error) {
+		out := []interface{}{}
+		for _, v := range in {
+			if m, ok := v.(map[string]interface{}); ok {
+				if f, ok := m[name]; ok {
+					out = append(out, f)
+				}
+			}
+		}
+		return out, nil
+	}
+}
+
+func jsonpath_index(n int) jsonpath_step {
+	return func(in []interface{}) ([]interface{}, error) {
+		out := []interface{}{}
+		for _, v := range in {
+			if l, ok := v.([]interface{}); ok {
+				i := n
+				if i < 0 {
+					i += len(l)
+				}
+				if i >= 0 && i < len(l) {
+					out = append(out, l[i])
+				}
+			}
+		}
+		return out, nil
+	}
+}
+
+func jsonpath_wildcard(in []interface{}) ([]interface{}, error) {
+	out := []interface{}{}
+	for _, v := range in {
+		out = append(out, jsonpath_children(v)...)
+	}
+	return out, nil
+}
+
+func jsonpath_children(v interface{}) []interface{} {
+	switch r := v.(type) {
+	case []interface{}:
+		return r
+	case map[string]interface{}:
+		keys := make([]string, 0, len(r))
+		for k := range r {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make([]interface{}, len(keys))
+		for i, k := range keys {
+			out[i] = r[k]
+		}
+		return out
+	}
+	return nil
+}
+
+func jsonpath_descent(name string) jsonpath_step {
+	var collect func(v interface{}, out []interface{}) []interface{}
+	collect = func(v interface{}, out []interface{}) []interface{} {
+		if m, ok := v.(map[string]interface{}); ok {
+			if f, ok := m[name]; ok {
+				out = append(out, f)
+			}
+		}
+		for _, c := range jsonpath_children(v) {
+			out = collect(c, out)
+		}
+		return out
+	}
+	return func(in []interface{}) ([]interface{}, error) {
+		out := []interface{}{}
+		for _, v := range in {
+			out = collect(v, out)
+		}
+		return out, nil
+	}
+}
+
+// jsonpath_operators are checked in this order at every position,
+// so longer operators must precede their prefixes.
+var jsonpath_operators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// jsonpath_filter_operator finds the first comparison operator of a
+// filter expression outside of quotes and brackets.
+func jsonpath_filter_operator(s string) (int, string) {
+	level := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '(':
+			level++
+		case c == ']' || c == ')':
+			level--
+		case level == 0:
+			for _, o := range jsonpath_operators {
+				if strings.HasPrefix(s[i:], o) {
+					return i, o
+				}
+			}
+		}
+	}
+	return -1, ""
+}
+
+func parse_jsonpath_filter(s string) (jsonpath_step, error) {
+	left, right := s, ""
+	i, op := jsonpath_filter_operator(s)
+	if i >= 0 {
+		left, right = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(op):])
+	}
+	if !strings.HasPrefix(left, "@") {
+		return nil, fmt.Errorf("filter must start with '@': %s", s)
+	}
+	path, err := parse_jsonpath_steps(left[1:])
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if op != "" {
+		if len(right) >= 2 && (right[0] == '\'' || right[0] == '"') && right[len(right)-1] == right[0] {
+			value = right[1 : len(right)-1]
+		} else if f, err := strconv.ParseFloat(right, 64); err == nil {
+			value = f
+		} else {
+			return nil, fmt.Errorf("invalid filter value '%s'", right)
+		}
+	}
+	match := func(v interface{}) bool {
+		values := []interface{}{v}
+		for _, s := range path {
+			values, _ = s(values)
+		}
+		if op == "" {
+			return len(values) > 0
+		}
+		for _, r := range values {
+			if jsonpath_compare(r, op, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(in []interface{}) ([]interface{}, error) {
+		out := []interface{}{}
+		for _, v := range in {
+			for _, c := range jsonpath_children(v) {
+				if match(c) {
+					out = append(out, c)
+				}
+			}
+		}
+		return out, nil
+	}, nil
+}
+
+func jsonpath_compare(a interface{}, op string, b interface{}) bool {
+	var c int
+	switch bv := b.(type) {
+	case float64:
+		av, ok := a.(float64)
+		if !ok {
+			return op == "!="
+		}
+		switch {
+		case av < bv:
+			c = -1
+		case av > bv:
+			c = 1
+		}
+	case string:
+		av, ok := a.(string)
+		if !ok {
+			av = JSONValueString(a)
+		}
+		c = strings.Compare(av, bv)
+	}
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////
+
+// JSONPathTemplate is a text with embedded JSONPath expressions in
+// braces as used by kubectl, e.g. "{.metadata.name}{"\n"}". It supports
+// string literals and iterations with {range <expr>}...{end}. Multiple
+// values of an expression are separated by a space.
+type JSONPathTemplate struct {
+	nodes []*jsonpath_node
+}
+
+type jsonpath_node struct {
+	text   string
+	path   *JSONPath
+	range_ []*jsonpath_node
+}
+
+func ParseJSONPathTemplate(tmpl string) (*JSONPathTemplate, error) {
+	if !strings.Contains(tmpl, "{") {
+		tmpl = "{" + tmpl + "}"
+	}
+	nodes, rest, err := parse_jsonpath_nodes(tmpl, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath template: %s", err)
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("invalid jsonpath template: unexpected {end}")
+	}
+	return &JSONPathTemplate{nodes}, nil
+}
+
+func parse_jsonpath_nodes(s string, inrange bool) ([]*jsonpath_node, string, error) {
+	nodes := []*jsonpath_node{}
+	for len(s) > 0 {
+		i := strings.Index(s, "{")
+		if i < 0 {
+			nodes = append(nodes, &jsonpath_node{text: s})
+			s = ""
+			break
+		}
+		if i > 0 {
+			nodes = append(nodes, &jsonpath_node{text: s[:i]})
+		}
+		end := jsonpath_brace_end(s[i:])
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated '{'")
+		}
+		expr := strings.TrimSpace(s[i+1 : i+end])
+		s = s[i+end+1:]
+		switch {
+		case expr == "end":
+			if !inrange {
+				return nodes, "{end}", nil
+			}
+			return nodes, s, nil
+		case strings.HasPrefix(expr, "range "):
+			path, err := ParseJSONPath(strings.TrimSpace(expr[6:]))
+			if err != nil {
+				return nil, "", err
+			}
+			sub, rest, err := parse_jsonpath_nodes(s, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, &jsonpath_node{path: path, range_: sub})
+			s = rest
+		case len(expr) >= 2 && (expr[0] == '"' || expr[0] == '\''):
+			text, err := strconv.Unquote("\"" + expr[1:len(expr)-1] + "\"")
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid string literal %s", expr)
+			}
+			nodes = append(nodes, &jsonpath_node{text: text})
+		default:
+			path, err := ParseJSONPath(expr)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, &jsonpath_node{path: path})
+		}
+	}
+	if inrange {
+		return nil, "", fmt.Errorf("missing {end}")
+	}
+	return nodes, "", nil
+}
+
+// jsonpath_brace_end finds the closing brace respecting quotes.
+func jsonpath_brace_end(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func (this *JSONPathTemplate) Execute(w io.Writer, data interface{}) error {
+	return execute_jsonpath_nodes(w, this.nodes, data)
+}
+
+func execute_jsonpath_nodes(w io.Writer, nodes []*jsonpath_node, data interface{}) error {
+	for _, n := range nodes {
+		if n.path == nil {
+			if _, err := io.WriteString(w, n.text); err != nil {
+				return err
+			}
+			continue
+		}
+		values, err := n.path.Eval(data)
+		if err != nil {
+			return err
+		}
+		if n.range_ != nil {
+			for _, v := range values {
+				if err := execute_jsonpath_nodes(w, n.range_, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		cells := make([]string, len(values))
+		for i, v := range values {
+			cells[i] = JSONValueString(v)
+		}
+		if _, err := io.WriteString(w, strings.Join(cells, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
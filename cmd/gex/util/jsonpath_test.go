@@ -0,0 +1,136 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const jsonpath_test_data = `{
+  "metadata": {"name": "shoot1", "labels": {"a": "1", "b": "2"}},
+  "items": [
+    {"name": "a", "value": "a==b", "count": 1},
+    {"name": "b", "value": "x<y", "count": 2},
+    {"name": "c", "value": "c", "count": 3, "extra": {"name": "d"}}
+  ]
+}`
+
+func jsonpath_data(t *testing.T) interface{} {
+	var data interface{}
+	if err := json.Unmarshal([]byte(jsonpath_test_data), &data); err != nil {
+		t.Fatalf("invalid test data: %s", err)
+	}
+	return data
+}
+
+func TestJSONPathEval(t *testing.T) {
+	data := jsonpath_data(t)
+	tests := []struct {
+		expr   string
+		result []string
+	}{
+		{".metadata.name", []string{"shoot1"}},
+		{"{.metadata.name}", []string{"shoot1"}},
+		{"$.metadata['name']", []string{"shoot1"}},
+		{".metadata.labels.*", []string{"1", "2"}},
+		{".items[*].name", []string{"a", "b", "c"}},
+		{".items[-1].name", []string{"c"}},
+		{".items[5].name", []string{}},
+		{"..name", []string{"a", "b", "c", "d", "shoot1"}},
+		{".items[?(@.extra)].name", []string{"c"}},
+		{".items[?(@.count>=2)].name", []string{"b", "c"}},
+		{".items[?(@.count<2)].name", []string{"a"}},
+		{".items[?(@.count!=2)].name", []string{"a", "c"}},
+		{`.items[?(@.value=="a==b")].name`, []string{"a"}},
+		{`.items[?(@.value<"a==b")].name`, []string{}},
+		{`.items[?(@.value>"a==b")].name`, []string{"b", "c"}},
+		{`.items[?(@.value=='x<y')].name`, []string{"b"}},
+		{`.items[?(@.value!="x<y")].name`, []string{"a", "c"}},
+	}
+	for _, test := range tests {
+		p, err := ParseJSONPath(test.expr)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.expr, err)
+			continue
+		}
+		values, err := p.Eval(data)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.expr, err)
+			continue
+		}
+		result := make([]string, len(values))
+		for i, v := range values {
+			result[i] = JSONValueString(v)
+		}
+		if !reflect.DeepEqual(result, test.result) {
+			t.Errorf("%s: expected %v, found %v", test.expr, test.result, result)
+		}
+	}
+}
+
+func TestJSONPathErrors(t *testing.T) {
+	tests := []string{
+		".items[*",
+		".items[x]",
+		".items[?(.name==\"a\")]",
+		".items[?(@.name==a)]",
+		"..",
+	}
+	for _, expr := range tests {
+		if _, err := ParseJSONPath(expr); err == nil {
+			t.Errorf("%s: error expected", expr)
+		}
+	}
+}
+
+func TestJSONPathFilterOperator(t *testing.T) {
+	tests := []struct {
+		expr  string
+		index int
+		op    string
+	}{
+		{"@.x", -1, ""},
+		{"@.x==1", 3, "=="},
+		{"@.x<=1", 3, "<="},
+		{"@.x<1", 3, "<"},
+		{`@.x<"a==b"`, 3, "<"},
+		{`@.x!='a<b'`, 3, "!="},
+		{`@.x[?(@.y==1)].z>2`, 16, ">"},
+	}
+	for _, test := range tests {
+		index, op := jsonpath_filter_operator(test.expr)
+		if index != test.index || op != test.op {
+			t.Errorf("%s: expected %q at %d, found %q at %d", test.expr, test.op, test.index, op, index)
+		}
+	}
+}
+
+func TestJSONPathTemplate(t *testing.T) {
+	data := jsonpath_data(t)
+	tests := []struct {
+		tmpl   string
+		result string
+	}{
+		{".metadata.name", "shoot1"},
+		{`{.metadata.name}{"\n"}`, "shoot1\n"},
+		{"{.items[*].name}", "a b c"},
+		{`{range .items[*]}{.name}={.count}{";"}{end}`, "a=1;b=2;c=3;"},
+		{`{range .items[?(@.value=="a==b")]}{.name}{end}`, "a"},
+	}
+	for _, test := range tests {
+		tmpl, err := ParseJSONPathTemplate(test.tmpl)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.tmpl, err)
+			continue
+		}
+		buf := &bytes.Buffer{}
+		if err := tmpl.Execute(buf, data); err != nil {
+			t.Errorf("%s: unexpected error: %s", test.tmpl, err)
+			continue
+		}
+		if buf.String() != test.result {
+			t.Errorf("%s: expected %q, found %q", test.tmpl, test.result, buf.String())
+		}
+	}
+}
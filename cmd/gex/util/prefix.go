@@ -0,0 +1,51 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter writes complete lines prefixed by a fixed string to
+// a shared writer. The lock is shared among all writers of the same
+// target to avoid interleaved lines.
+type PrefixWriter struct {
+	writer io.Writer
+	lock   sync.Locker
+	prefix []byte
+	buf    bytes.Buffer
+}
+
+var _ io.WriteCloser = &PrefixWriter{}
+
+func NewPrefixWriter(w io.Writer, lock sync.Locker, prefix string) *PrefixWriter {
+	return &PrefixWriter{writer: w, lock: lock, prefix: []byte(prefix)}
+}
+
+func (this *PrefixWriter) Write(data []byte) (int, error) {
+	this.buf.Write(data)
+	for {
+		i := bytes.IndexByte(this.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(data), nil
+		}
+		if err := this.line(this.buf.Next(i + 1)); err != nil {
+			return len(data), err
+		}
+	}
+}
+
+// Close flushes a pending incomplete line.
+func (this *PrefixWriter) Close() error {
+	if this.buf.Len() == 0 {
+		return nil
+	}
+	return this.line(append(this.buf.Next(this.buf.Len()), '\n'))
+}
+
+func (this *PrefixWriter) line(line []byte) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	_, err := this.writer.Write(append(append([]byte{}, this.prefix...), line...))
+	return err
+}
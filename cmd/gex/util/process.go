@@ -2,9 +2,11 @@ package util
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"syscall"
 )
 
 /////////////////////////////////////////////////////////////////////////////////
@@ -22,6 +24,78 @@ func Kubectl(config []byte, input []byte, args ...string) error {
 	return ExecProcess(input, files, "kubectl", eff...)
 }
 
+// KubectlTo runs kubectl without a terminal and passes its output to
+// the given writers. It returns the exit code of kubectl.
+func KubectlTo(config []byte, stdout, stderr io.Writer, args ...string) (int, error) {
+	ci, err := NewTempFileInput(config)
+	if err != nil {
+		return -1, err
+	}
+	defer ci.CleanupFunction()()
+
+	_, cfgPath := ci.InheritedFiles(nil)
+
+	cmd := exec.Command("kubectl", append([]string{fmt.Sprintf("--kubeconfig=%s", cfgPath)}, args...)...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	err = cmd.Run()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			if status, ok := ee.Sys().(syscall.WaitStatus); ok {
+				return status.ExitStatus(), nil
+			}
+		}
+		return -1, err
+	}
+	return 0, nil
+}
+
+// ExitError reports the exit code of a failed command. It is used as
+// exit code of gex, so scripts can evaluate the result of the command.
+type ExitError struct {
+	Command string
+	Code    int
+}
+
+func (this *ExitError) Error() string {
+	return fmt.Sprintf("%s exited with code %d", this.Command, this.Code)
+}
+
+var last_exit *ExitError
+
+// LastExitError provides the exit error of the last failed command.
+// The command table wraps the errors of commands, so the exit code
+// cannot be taken from the error returned for a gex command.
+func LastExitError() *ExitError {
+	return last_exit
+}
+
+// exit_error maps the exit status of a failed command to an ExitError.
+func exit_error(c string, err error) error {
+	if ee, ok := err.(*exec.ExitError); ok {
+		if status, ok := ee.Sys().(syscall.WaitStatus); ok && status.ExitStatus() > 0 {
+			last_exit = &ExitError{c, status.ExitStatus()}
+			return last_exit
+		}
+	}
+	return err
+}
+
+// ExecProcessEnv runs a command attached to the terminal with additional
+// environment settings.
+func ExecProcessEnv(env []string, c string, args ...string) error {
+	cmd := exec.Command(c, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	cmd.Env = append(os.Environ(), env...)
+	err := cmd.Run()
+	if err == nil && !cmd.ProcessState.Success() {
+		return fmt.Errorf("command failed")
+	}
+	return exit_error(c, err)
+}
+
 func ExecProcess(input []byte, extra []*os.File, c string, args ...string) error {
 	var stdin = os.Stdin
 	if input != nil {
@@ -52,7 +126,7 @@ func ExecProcess(input []byte, extra []*os.File, c string, args ...string) error
 	if err == nil && !cmd.ProcessState.Success() {
 		return fmt.Errorf("command failed")
 	}
-	return err
+	return exit_error(c, err)
 }
 
 func ExecCmd(cmd string, extra []*os.File, environment ...string) (err error) {
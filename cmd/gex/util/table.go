@@ -2,9 +2,35 @@ package util
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
+var escape_sequence = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// VisibleLen provides the printed width of a string ignoring
+// terminal escape sequences used for styling.
+func VisibleLen(s string) int {
+	if strings.IndexByte(s, '\033') >= 0 {
+		s = escape_sequence.ReplaceAllString(s, "")
+	}
+	return utf8.RuneCountInString(s)
+}
+
+// Pad pads a string with blanks to the given visible width. The
+// format "-" aligns left, "" aligns right.
+func Pad(s string, width int, format string) string {
+	n := width - VisibleLen(s)
+	if n <= 0 {
+		return s
+	}
+	if format == "-" {
+		return s + strings.Repeat(" ", n)
+	}
+	return strings.Repeat(" ", n) + s
+}
+
 func FormatTable(gap string, data [][]string) {
 	columns := []int{}
 	max := 0
@@ -23,15 +49,16 @@ func FormatTable(gap string, data [][]string) {
 
 	for _, row := range data {
 		for i, col := range row {
+			l := VisibleLen(col)
 			if i >= len(columns) {
-				columns = append(columns, len(col))
+				columns = append(columns, l)
 			} else {
-				if columns[i] < len(col) {
-					columns[i] = len(col)
+				if columns[i] < l {
+					columns[i] = l
 				}
 			}
-			if len(col) > max {
-				max = len(col)
+			if l > max {
+				max = l
 			}
 		}
 	}
@@ -58,30 +85,22 @@ func FormatTable(gap string, data [][]string) {
 			}
 		}
 	} else {
-		format := gap
-		for i, col := range columns {
-			f := "-"
-			if i < len(formats) {
-				f = formats[i]
-			}
-			if i == len(columns)-1 && f == "-" {
-				format = fmt.Sprintf("%s%%s ", format)
-			} else {
-				format = fmt.Sprintf("%s%%%s%ds ", format, f, col)
-			}
-		}
-		format = format[:len(format)-1] + "\n"
 		for _, row := range data {
 			if len(row) > 0 {
-				r := []interface{}{}
-				for i := 0; i < len(columns); i++ {
+				cells := make([]string, len(columns))
+				for i, width := range columns {
+					f := "-"
+					if i < len(formats) {
+						f = formats[i]
+					}
 					if i < len(row) {
-						r = append(r, row[i])
-					} else {
-						r = append(r, "")
+						cells[i] = row[i]
+					}
+					if i < len(columns)-1 || f != "-" {
+						cells[i] = Pad(cells[i], width, f)
 					}
 				}
-				fmt.Printf(format, r...)
+				fmt.Printf("%s%s\n", gap, strings.Join(cells, " "))
 			}
 		}
 	}
@@ -0,0 +1,127 @@
+package util
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TableFormat renders a table. A leading - of a header field denotes a
+// right aligned column. If noheader is set the header is omitted
+// for formats supporting it.
+type TableFormat func(w io.Writer, header []string, rows [][]string, noheader bool) error
+
+var TableFormats = map[string]TableFormat{
+	"csv":      FormatCSV,
+	"tsv":      FormatTSV,
+	"markdown": FormatMarkdown,
+	"ndjson":   FormatNDJSON,
+}
+
+func header_names(header []string) []string {
+	names := make([]string, len(header))
+	for i, h := range header {
+		names[i] = strings.TrimPrefix(h, "-")
+	}
+	return names
+}
+
+func FormatCSV(w io.Writer, header []string, rows [][]string, noheader bool) error {
+	out := csv.NewWriter(w)
+	if !noheader {
+		out.Write(header_names(header))
+	}
+	for _, r := range rows {
+		out.Write(r)
+	}
+	out.Flush()
+	return out.Error()
+}
+
+var tsv_escaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+// FormatTSV renders tab separated values. Tabs, newlines and
+// backslashes in values are escaped with a backslash.
+func FormatTSV(w io.Writer, header []string, rows [][]string, noheader bool) error {
+	write := func(r []string) error {
+		cells := make([]string, len(r))
+		for i, c := range r {
+			cells[i] = tsv_escaper.Replace(c)
+		}
+		_, err := fmt.Fprintln(w, strings.Join(cells, "\t"))
+		return err
+	}
+	if !noheader {
+		if err := write(header_names(header)); err != nil {
+			return err
+		}
+	}
+	for _, r := range rows {
+		if err := write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var markdown_escaper = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+// FormatMarkdown renders a GitHub flavored markdown table. The header
+// is always rendered, because it is required by the format.
+func FormatMarkdown(w io.Writer, header []string, rows [][]string, noheader bool) error {
+	write := func(r []string) error {
+		cells := make([]string, len(header))
+		for i := range cells {
+			if i < len(r) {
+				cells[i] = markdown_escaper.Replace(r[i])
+			}
+		}
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		return err
+	}
+	if err := write(header_names(header)); err != nil {
+		return err
+	}
+	sep := make([]string, len(header))
+	for i, h := range header {
+		sep[i] = "---"
+		if strings.HasPrefix(h, "-") {
+			sep[i] = "--:"
+		}
+	}
+	if _, err := fmt.Fprintf(w, "|%s|\n", strings.Join(sep, "|")); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatNDJSON renders one json object per row using the lower case
+// header fields as keys.
+func FormatNDJSON(w io.Writer, header []string, rows [][]string, noheader bool) error {
+	keys := header_names(header)
+	for i, k := range keys {
+		keys[i] = strings.ToLower(k)
+	}
+	enc := json.NewEncoder(w)
+	for _, r := range rows {
+		obj := map[string]string{}
+		for i, c := range r {
+			if i < len(keys) {
+				obj[keys[i]] = c
+			} else {
+				obj[fmt.Sprintf("column%d", i+1)] = c
+			}
+		}
+		if err := enc.Encode(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// IsTerminal checks whether the standard output is a terminal.
+func IsTerminal() bool {
+	return terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// TableStream prints table rows as soon as they are available.
+// The column widths are taken from the header and optional width hints.
+// They grow if a row requires more space, so only the following rows
+// are aligned to the new width.
+// On a terminal a progress footer can be shown below the rows.
+type TableStream struct {
+	lock    sync.Mutex
+	out     io.Writer
+	gap     string
+	formats []string
+	widths  []int
+	footer  string
+	rows    int
+	closed  bool
+}
+
+func NewTableStream(gap string, header []string, hints ...int) *TableStream {
+	return (&TableStream{}).new(os.Stdout, gap, header, hints)
+}
+
+func NewTableStreamWriter(out io.Writer, gap string, header []string, hints ...int) *TableStream {
+	return (&TableStream{}).new(out, gap, header, hints)
+}
+
+func (this *TableStream) new(out io.Writer, gap string, header []string, hints []int) *TableStream {
+	this.out = out
+	this.gap = gap
+	cols := make([]string, len(header))
+	for i, f := range header {
+		if strings.HasPrefix(f, "-") {
+			this.formats = append(this.formats, "")
+			cols[i] = f[1:]
+		} else {
+			this.formats = append(this.formats, "-")
+			cols[i] = f
+		}
+		w := len(cols[i])
+		if i < len(hints) && hints[i] > w {
+			w = hints[i]
+		}
+		this.widths = append(this.widths, w)
+	}
+	this.print(cols)
+	return this
+}
+
+// Row prints a table row.
+func (this *TableStream) Row(row []string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.clear()
+	this.print(row)
+	this.rows++
+	this.show()
+}
+
+// Footer sets the progress footer shown below the actual rows.
+func (this *TableStream) Footer(msg string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.closed {
+		return
+	}
+	this.clear()
+	this.footer = msg
+	this.show()
+}
+
+// Close removes the progress footer.
+func (this *TableStream) Close() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.clear()
+	this.footer = ""
+	this.closed = true
+}
+
+func (this *TableStream) Rows() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.rows
+}
+
+func (this *TableStream) print(row []string) {
+	line := this.gap
+	for i, col := range row {
+		if i >= len(this.widths) {
+			this.widths = append(this.widths, 0)
+			this.formats = append(this.formats, "-")
+		}
+		if l := VisibleLen(col); l > this.widths[i] {
+			this.widths[i] = l
+		}
+		if i == len(row)-1 && this.formats[i] == "-" {
+			line += col
+		} else {
+			line += Pad(col, this.widths[i], this.formats[i]) + " "
+		}
+	}
+	fmt.Fprintln(this.out, strings.TrimRight(line, " "))
+}
+
+func (this *TableStream) show() {
+	if this.footer != "" {
+		fmt.Fprintf(this.out, "%s", this.footer)
+	}
+}
+
+func (this *TableStream) clear() {
+	if this.footer != "" {
+		fmt.Fprintf(this.out, "\r\033[K")
+	}
+}
@@ -16,4 +16,14 @@ func init() {
 		CatchUnknownCommand(catch_cluster).Raw().
 		CmdArgDescription("{<kubectl opts/args>}").
 		CmdDescription("run kubectl for garden cluster")
+
+	NewVerb("exec", cmdint.MainTab()).CmdArgDescription("<type> ...").
+		CmdDescription("general exec command",
+			"The first argument is the element type followed by",
+			"element name option and the command to execute.",
+			"If no element option is given, it must be defaulted by the",
+			"selection command or the appropriate selection option.",
+			"If nothing is selected the command is run for the garden cluster.",
+		).
+		CatchUnknownCommand(catch_cluster).Raw()
 }
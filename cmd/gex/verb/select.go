@@ -3,7 +3,6 @@ package verb
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/mandelsoft/cmdint/pkg/cmdint"
 
@@ -70,15 +69,14 @@ func cmd_select(opts *cmdint.Options) error {
 	} else {
 		if download {
 			if !data.IsEmpty(shp) {
-				a := strings.Split(*shp, "/")
-				s, err := ctx.Garden.GetShoot(gube.NewShootName(a[0], a[1]))
+				s, err := ctx.GetShootByName(*shp)
 				if err != nil {
 					return err
 				}
 				return Download(ctx.ByKubeconfig, export, s, ctx.CacheDirForShoot(s))
 			} else {
 				if !data.IsEmpty(sep) {
-					s, err := ctx.Garden.GetSeed(*sep)
+					s, err := ctx.GetSeedByName(*sep)
 					if err != nil {
 						return err
 					}
@@ -169,6 +167,7 @@ func (this *select_output) Out(ctx *context.Context) error {
 			Download(ctx.ByKubeconfig, this.export, e, ctx.CacheDirForGarden())
 		}
 	case gube.Shoot:
+		garden = gardenName(e.Garden(), garden)
 		shoot = e.GetName().String()
 		project = e.GetName().GetProjectName()
 		seed = e.GetSeedName()
@@ -176,6 +175,7 @@ func (this *select_output) Out(ctx *context.Context) error {
 			err = Download(ctx.ByKubeconfig, this.export, e, ctx.CacheDirForShoot(e))
 		}
 	case gube.Seed:
+		garden = gardenName(e.Garden(), garden)
 		seed = e.GetName()
 		if this.download {
 			err = Download(ctx.ByKubeconfig, this.export, e, ctx.CacheDirForSeed(e))
@@ -190,6 +190,13 @@ func (this *select_output) Out(ctx *context.Context) error {
 	return err
 }
 
+func gardenName(g gube.Garden, def string) string {
+	if g != nil && g.GetName() != "" {
+		return g.GetName()
+	}
+	return def
+}
+
 func (this *select_output) Write(garden, shoot, project, seed *string) {
 	env.Warning()
 	envout(garden, "GARDEN")
@@ -25,13 +25,19 @@ func init() {
 		SimpleCommand("garden", cmd_shell_garden).
 		ArgOption(constants.O_NODE).Short('n').ArgDescription("<name>").Description("node name").
 		ArgOption(constants.O_POD).Short('p').ArgDescription("<name>").Description("pod name").
+		FlagOption(constants.O_REAP).Description("delete abandoned shell pods").
 		CmdDescription("run shell for garden cluster")
 }
 
 func cmd_shell_garden(opts *cmdint.Options) error {
 	fmt.Printf("using garden: %v\n", opts.Arguments)
 	ctx := context.Get(opts)
-	out := output.NewShellOutput(opts.GetOptionValue(constants.O_NODE), opts.GetOptionValue(constants.O_POD), nil)
+	var out output.Output
+	if opts.IsFlag(constants.O_REAP) {
+		out = output.NewShellReapOutput(nil)
+	} else {
+		out = output.NewShellOutput(opts.GetOptionValue(constants.O_NODE), opts.GetOptionValue(constants.O_POD), nil)
+	}
 	out.Add(ctx, ctx.Garden)
 	return out.Out(ctx)
 }
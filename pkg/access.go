@@ -0,0 +1,28 @@
+package gube
+
+import (
+	"sync"
+)
+
+// AccessRecorder is called whenever credentials are fetched for
+// a garden element. kind describes the kind of credential
+// (for example kubeconfig or secret) and name its name.
+type AccessRecorder func(elem interface{}, kind string, name string)
+
+var recorder AccessRecorder
+var recorderLock sync.RWMutex
+
+func SetAccessRecorder(r AccessRecorder) {
+	recorderLock.Lock()
+	defer recorderLock.Unlock()
+	recorder = r
+}
+
+func recordAccess(elem interface{}, kind string, name string) {
+	recorderLock.RLock()
+	r := recorder
+	recorderLock.RUnlock()
+	if r != nil {
+		r(elem, kind, name)
+	}
+}
@@ -2,8 +2,19 @@ package gube
 
 import (
 	"fmt"
+	"path/filepath"
+
+	"github.com/afritzler/garden-examiner/pkg/data"
+)
+
+const (
+	CACHE_SHOOTS   = "shoots"
+	CACHE_PROJECTS = "projects"
+	CACHE_PROFILES = "profiles"
 )
 
+var CacheTypes = []string{CACHE_SHOOTS, CACHE_PROJECTS, CACHE_PROFILES}
+
 var _ = fmt.Errorf
 
 type CachedGarden interface {
@@ -24,6 +35,13 @@ func NewCachedGarden(g Garden) CachedGarden {
 	return (&cached_garden{}).new(g)
 }
 
+// NewPersistentCachedGarden provides a cached garden keeping the element
+// lists in the given directory according to the cache config.
+// With refresh set the stored lists are not used, but updated.
+func NewPersistentCachedGarden(g Garden, dir string, cfg *CacheConfig, refresh bool) (CachedGarden, error) {
+	return (&cached_garden{}).newPersistent(g, dir, cfg, refresh)
+}
+
 func (this *cached_garden) new(g Garden) CachedGarden {
 	this.Garden = g.NewWrapper(this)
 	this.projects = NewProjectCache(this.Garden)
@@ -32,6 +50,41 @@ func (this *cached_garden) new(g Garden) CachedGarden {
 	return this
 }
 
+func (this *cached_garden) newPersistent(g Garden, dir string, cfg *CacheConfig, refresh bool) (CachedGarden, error) {
+	stores, err := NewCacheStores(this, dir, cfg, refresh)
+	if err != nil {
+		return nil, err
+	}
+	this.Garden = g.NewWrapper(this)
+	this.projects = NewPersistentProjectCache(this.Garden, stores[CACHE_PROJECTS])
+	this.profiles = NewPersistentProfileCache(this.Garden, stores[CACHE_PROFILES])
+	this.shoots = NewPersistentShootCache(this.Garden, stores[CACHE_SHOOTS])
+	return this, nil
+}
+
+// NewCacheStores provides the persistent stores for the element types
+// of a garden located in the given directory. Stores of element types
+// without ttl keep no content.
+func NewCacheStores(g Garden, dir string, cfg *CacheConfig, refresh bool) (map[string]data.CacheStore, error) {
+	stores := map[string]data.CacheStore{}
+	if dir == "" || cfg == nil {
+		return stores, nil
+	}
+	codecs := map[string]data.ElementCodec{
+		CACHE_SHOOTS:   NewShootCodec(g),
+		CACHE_PROJECTS: NewProjectCodec(g),
+		CACHE_PROFILES: NewProfileCodec(g),
+	}
+	for _, kind := range CacheTypes {
+		ttl, err := cfg.GetTTL(kind)
+		if err != nil {
+			return nil, err
+		}
+		stores[kind] = data.NewFileCacheStore(filepath.Join(dir, kind+".json"), ttl, codecs[kind], refresh)
+	}
+	return stores, nil
+}
+
 func (this *cached_garden) Reset() {
 	this.projects.Reset()
 	this.profiles.Reset()
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"sync"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/mandelsoft/filepath/pkg/filepath"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -17,6 +19,56 @@ type GardenSetConfig interface {
 	GetConfigs() map[string]GardenConfig
 	GetGithubURL() string
 	GetDefault() string
+	GetParallelism() *ParallelismConfig
+	GetCacheConfig() *CacheConfig
+	GetColumnSets() map[string][]string
+}
+
+// ParallelismConfig limits the number of parallel requests. The key
+// limits apply to the target of a request, which is the seed for
+// shoot requests or the garden. A negative key limit disables the
+// default limit per target. The timeout limits the duration of
+// a single request (e.g. "30s").
+type ParallelismConfig struct {
+	Limit    int                  `yaml:"limit,omitempty" json:"limit,omitempty"`
+	KeyLimit int                  `yaml:"keyLimit,omitempty" json:"keyLimit,omitempty"`
+	Keys     map[string]KeyConfig `yaml:"keys,omitempty" json:"keys,omitempty"`
+	Timeout  string               `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+type KeyConfig struct {
+	Limit    int `yaml:"limit,omitempty" json:"limit,omitempty"`
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+}
+
+// CacheConfig enables the persistent element cache. The time to live
+// is configured per element type (shoots, profiles, projects) using
+// durations like "5m". Types without entry use a default ttl, a
+// ttl of "0" disables the persistent cache for a type.
+type CacheConfig struct {
+	TTL map[string]string `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+}
+
+var DefaultCacheTTL = map[string]time.Duration{
+	CACHE_SHOOTS:   2 * time.Minute,
+	CACHE_PROJECTS: 10 * time.Minute,
+	CACHE_PROFILES: time.Hour,
+}
+
+// GetTTL provides the ttl for an element type. A nil config disables
+// the persistent cache.
+func (this *CacheConfig) GetTTL(kind string) (time.Duration, error) {
+	if this == nil {
+		return 0, nil
+	}
+	if v, ok := this.TTL[kind]; ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid cache ttl for %s: %s", kind, err)
+		}
+		return d, nil
+	}
+	return DefaultCacheTTL[kind], nil
 }
 
 type GardenConfig interface {
@@ -24,9 +76,59 @@ type GardenConfig interface {
 	GetDescription() string
 	GetGarden() (Garden, error)
 	GetRuntimeObject() runtime.Object
+	GetShellConfig() *ShellConfig
 	KubeconfigProvider
 }
 
+// ShellConfig describes the pod used to run a root shell on a
+// cluster node. Unset fields are defaulted.
+type ShellConfig struct {
+	Image       string              `yaml:"image,omitempty" json:"image,omitempty"`
+	DebugImage  string              `yaml:"debugImage,omitempty" json:"debugImage,omitempty"`
+	Namespace   string              `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Tolerations []corev1.Toleration `yaml:"tolerations,omitempty" json:"tolerations,omitempty"`
+	Timeout     string              `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+var DefaultShellConfig = ShellConfig{
+	Image:      "busybox",
+	DebugImage: "busybox",
+	Namespace:  "default",
+	Tolerations: []corev1.Toleration{
+		corev1.Toleration{
+			Key:      "node-role.kubernetes.io/master",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	},
+	Timeout: "2m",
+}
+
+// Complete provides a copy of the shell config with all unset fields
+// taken from the default shell config.
+func (this *ShellConfig) Complete() *ShellConfig {
+	result := DefaultShellConfig
+	if this == nil {
+		return &result
+	}
+	if this.Image != "" {
+		result.Image = this.Image
+	}
+	if this.DebugImage != "" {
+		result.DebugImage = this.DebugImage
+	}
+	if this.Namespace != "" {
+		result.Namespace = this.Namespace
+	}
+	if this.Tolerations != nil {
+		result.Tolerations = this.Tolerations
+	}
+	if this.Timeout != "" {
+		result.Timeout = this.Timeout
+	}
+	return &result
+}
+
 func NewDefaultGardenSetConfig(g Garden) GardenSetConfig {
 	cfg := &GardenConfigImpl{
 		Name:        "default",
@@ -61,10 +163,13 @@ func ReadGardenSetConfig(path string) (*GardenSetConfigImpl, error) {
 /////////////////////////////////////////////////////////////////////////////
 
 type GardenSetConfigImpl struct {
-	GithubURL string              `yaml:"githubURL,omitempty" json:"githubURL,omitempty"`
-	Gardens   []*GardenConfigImpl `yaml:"gardens,omitempty" json:"gardens,omitempty"`
-	Default   string              `yaml:"default,omitempty" json:"default,omitempty"`
-	path      string
+	GithubURL   string              `yaml:"githubURL,omitempty" json:"githubURL,omitempty"`
+	Gardens     []*GardenConfigImpl `yaml:"gardens,omitempty" json:"gardens,omitempty"`
+	Default     string              `yaml:"default,omitempty" json:"default,omitempty"`
+	Parallelism *ParallelismConfig  `yaml:"parallelism,omitempty" json:"parallelism,omitempty"`
+	Cache       *CacheConfig        `yaml:"cache,omitempty" json:"cache,omitempty"`
+	Columns     map[string][]string `yaml:"columns,omitempty" json:"columns,omitempty"`
+	path        string
 }
 
 func (this *GardenSetConfigImpl) SetPath(path string) {
@@ -79,6 +184,20 @@ func (this *GardenSetConfigImpl) GetDefault() string {
 	return this.Default
 }
 
+func (this *GardenSetConfigImpl) GetParallelism() *ParallelismConfig {
+	return this.Parallelism
+}
+
+func (this *GardenSetConfigImpl) GetCacheConfig() *CacheConfig {
+	return this.Cache
+}
+
+// GetColumnSets provides the named column sets usable for the
+// custom-columns output (<header>[:<type>]:<jsonpath>).
+func (this *GardenSetConfigImpl) GetColumnSets() map[string][]string {
+	return this.Columns
+}
+
 func (this *GardenSetConfigImpl) GetGithubURL() string {
 	return this.GithubURL
 }
@@ -117,9 +236,10 @@ func (this *GardenSetConfigImpl) GetConfigs() map[string]GardenConfig {
 /////////////////////////////////////////////////////////////////////////////
 
 type GardenConfigImpl struct {
-	Name           string `yaml:"name,omitempty" json:"name,omitempty"`
-	KubeConfigPath string `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
-	Description    string `yaml:"description,omitempty" json:"description,omitempty"`
+	Name           string       `yaml:"name,omitempty" json:"name,omitempty"`
+	KubeConfigPath string       `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+	Description    string       `yaml:"description,omitempty" json:"description,omitempty"`
+	Shell          *ShellConfig `yaml:"shell,omitempty" json:"shell,omitempty"`
 	lock           sync.Mutex
 	kubeconfig     []byte
 	garden         Garden
@@ -142,6 +262,10 @@ func (this *GardenConfigImpl) GetDescription() string {
 	return this.Description
 }
 
+func (this *GardenConfigImpl) GetShellConfig() *ShellConfig {
+	return this.Shell.Complete()
+}
+
 func (this *GardenConfigImpl) GetKubeconfig() ([]byte, error) {
 	this.lock.Lock()
 	defer this.lock.Unlock()
@@ -180,7 +304,7 @@ func (this *GardenConfigImpl) _getGarden() (Garden, error) {
 		if path == "" {
 			path = this.KubeConfigPath
 		}
-		g, err := NewGardenFromConfigfile(path)
+		g, err := NewNamedGardenFromConfigfile(this.Name, path)
 		if err != nil {
 			return nil, fmt.Errorf("cannot create garden object for %s(%s)", this.Name, path)
 		}
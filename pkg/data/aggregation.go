@@ -0,0 +1,153 @@
+package data
+
+type KeyFunction func(interface{}) interface{}
+type ReduceFunction func(interface{}, interface{}) interface{}
+
+// Aggregation aggregates the elements of a group.
+type Aggregation func(key interface{}, elems IndexedSliceAccess) interface{}
+
+// Group is the element type provided by a GroupBy operation. Value
+// is the result of the group aggregation, if given.
+type Group struct {
+	Key      interface{}
+	Elements IndexedSliceAccess
+	Value    interface{}
+}
+
+// Count is an aggregation providing the number of group elements.
+func Count(key interface{}, elems IndexedSliceAccess) interface{} {
+	return len(elems)
+}
+
+// Reduction provides an aggregation reducing the group elements.
+func Reduction(initial interface{}, r ReduceFunction) Aggregation {
+	return func(key interface{}, elems IndexedSliceAccess) interface{} {
+		return reduce(elems, initial, r)
+	}
+}
+
+func Identity(e interface{}) interface{} {
+	return e
+}
+
+////////////////////////////////////////////////////////////////////////////
+
+func reduce(data Iterable, initial interface{}, r ReduceFunction) interface{} {
+	acc := initial
+	i := data.Iterator()
+	for i.HasNext() {
+		acc = r(acc, i.Next())
+	}
+	return acc
+}
+
+// group collects the elements by key in the order of the first
+// occurrence of a key.
+func group(data Iterable, k KeyFunction, control *processing_control) IndexedSliceAccess {
+	groups := map[interface{}]*Group{}
+	result := IndexedSliceAccess{}
+	i := data.Iterator()
+	for i.HasNext() && !control.cancelled() {
+		e := i.Next()
+		key := k(e)
+		g := groups[key]
+		if g == nil {
+			g = &Group{Key: key}
+			groups[key] = g
+			result = append(result, g)
+		}
+		g.Elements = append(g.Elements, e)
+	}
+	return result
+}
+
+func aggregate(a Aggregation) MappingFunction {
+	return func(e interface{}) interface{} {
+		g := e.(*Group)
+		if a != nil {
+			g.Value = a(g.Key, g.Elements)
+		}
+		return *g
+	}
+}
+
+func process_group(k KeyFunction, a Aggregation) processing {
+	return func(data Iterable, control *processing_control) Iterable {
+		groups := group(data, k, control)
+		m := aggregate(a)
+		for i, g := range groups {
+			groups[i] = m(g)
+		}
+		return groups
+	}
+}
+
+func process_reduce(initial interface{}, r ReduceFunction) processing {
+	return func(data Iterable, control *processing_control) Iterable {
+		return IndexedSliceAccess{reduce(data, initial, r)}
+	}
+}
+
+func process_distinct(k KeyFunction) processing {
+	if k == nil {
+		k = Identity
+	}
+	return func(data Iterable, control *processing_control) Iterable {
+		found := map[interface{}]bool{}
+		result := IndexedSliceAccess{}
+		i := data.Iterator()
+		for i.HasNext() && !control.cancelled() {
+			e := i.Next()
+			key := k(e)
+			if !found[key] {
+				found[key] = true
+				result = append(result, e)
+			}
+		}
+		return result
+	}
+}
+
+func process_range(skip, limit int) processing {
+	return func(data Iterable, control *processing_control) Iterable {
+		result := IndexedSliceAccess{}
+		i := data.Iterator()
+		for n := 0; i.HasNext() && (limit < 0 || n < skip+limit); n++ {
+			e := i.Next()
+			if n >= skip {
+				result = append(result, e)
+			}
+		}
+		return result
+	}
+}
+
+func process_batch(n int) processing {
+	return func(data Iterable, control *processing_control) Iterable {
+		result := IndexedSliceAccess{}
+		batch := IndexedSliceAccess{}
+		i := data.Iterator()
+		for i.HasNext() {
+			batch = append(batch, i.Next())
+			if len(batch) >= n {
+				result = append(result, batch)
+				batch = IndexedSliceAccess{}
+			}
+		}
+		if len(batch) > 0 {
+			result = append(result, batch)
+		}
+		return result
+	}
+}
+
+// CountBy provides the number of elements per key.
+func CountBy(data Iterable, k KeyFunction) map[interface{}]int {
+	counts := map[interface{}]int{}
+	i := Process(data).GroupBy(k, Count).Iterator()
+	for i.HasNext() {
+		g := i.Next().(Group)
+		counts[g.Key] = g.Value.(int)
+	}
+	return counts
+}
@@ -29,6 +29,8 @@ type UnsyncedCache interface {
 type _Cache struct {
 	lock     sync.Mutex
 	cacher   Cacher
+	store    CacheStore
+	loaded   bool
 	entries  map[interface{}]interface{}
 	complete bool
 }
@@ -39,6 +41,13 @@ func NewCache(c Cacher) Cache {
 	return &_Cache{cacher: c, entries: nil, complete: false}
 }
 
+// NewPersistentCache provides a cache using a persistent store to
+// keep the complete element list across processes. Errors accessing
+// the store are ignored, the elements are then taken from the cacher.
+func NewPersistentCache(c Cacher, s CacheStore) Cache {
+	return &_Cache{cacher: c, store: s, entries: nil, complete: false}
+}
+
 func (this *_Cache) Key(e interface{}) interface{} {
 	return this.cacher.Key(e)
 }
@@ -64,16 +73,21 @@ func (this *_Cache) GetAll() (Iterator, error) {
 }
 
 func (this *_Cache) NotSynced_GetAll() (Iterator, error) {
-	if this.entries == nil || !this.complete {
+	if (this.entries == nil || !this.complete) && !this.load() {
 		elems, err := this.cacher.GetAll()
 		if err != nil {
 			return nil, err
 		}
 		this.entries = map[interface{}]interface{}{}
 		this.complete = true
+		list := []interface{}{}
 		for elems.HasNext() {
 			e := elems.Next()
 			this.entries[this.cacher.Key(e)] = e
+			list = append(list, e)
+		}
+		if this.store != nil {
+			this.store.Store(list)
 		}
 	}
 	return NewMappedIterator(newMapEntryIterator(this.entries), func(e interface{}) interface{} {
@@ -92,6 +106,9 @@ func (this *_Cache) NotSynced_Get(key interface{}) (interface{}, error) {
 	if this.entries != nil {
 		p = this.entries[key]
 	}
+	if p == nil && !this.complete && this.load() {
+		p = this.entries[key]
+	}
 	if p == nil && !this.complete {
 		elem, err := this.cacher.Get(key)
 		if err != nil {
@@ -109,6 +126,26 @@ func (this *_Cache) NotSynced_Get(key interface{}) (interface{}, error) {
 	return p, nil
 }
 
+// load fills the cache from the persistent store, if available.
+// The store is consulted only once, after a Reset the elements are
+// always taken from the cacher.
+func (this *_Cache) load() bool {
+	if this.store == nil || this.loaded {
+		return false
+	}
+	this.loaded = true
+	elems, err := this.store.Load()
+	if err != nil || elems == nil {
+		return false
+	}
+	this.entries = map[interface{}]interface{}{}
+	this.complete = true
+	for _, e := range elems {
+		this.entries[this.cacher.Key(e)] = e
+	}
+	return true
+}
+
 func (this *_Cache) Iterator() Iterator {
 	this.lock.Lock()
 	defer this.lock.Unlock()
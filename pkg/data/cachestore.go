@@ -0,0 +1,156 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheStore is a persistent backend for a Cache. Load returns nil
+// if there is no valid content.
+type CacheStore interface {
+	Load() ([]interface{}, error)
+	Store(elems []interface{}) error
+	Stat() (*CacheStoreInfo, error)
+	Clear() error
+}
+
+// ElementCodec converts cached elements to and from their persistent
+// representation.
+type ElementCodec interface {
+	Encode(e interface{}) (interface{}, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+type CacheStoreInfo struct {
+	Path      string
+	Count     int
+	Timestamp time.Time
+	TTL       time.Duration
+}
+
+// Empty checks whether there is stored content at all.
+func (this *CacheStoreInfo) Empty() bool {
+	return this.Timestamp.IsZero()
+}
+
+func (this *CacheStoreInfo) Age() time.Duration {
+	return time.Since(this.Timestamp)
+}
+
+func (this *CacheStoreInfo) Expired() bool {
+	return this.TTL <= 0 || this.Age() > this.TTL
+}
+
+type file_content struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Elements  []json.RawMessage `json:"elements"`
+}
+
+type _FileCacheStore struct {
+	path    string
+	ttl     time.Duration
+	codec   ElementCodec
+	refresh bool
+}
+
+var _ CacheStore = &_FileCacheStore{}
+
+// NewFileCacheStore provides a cache store keeping the elements in a
+// json file. Content older than the given ttl is ignored. With refresh
+// set the stored content is ignored, but updated.
+func NewFileCacheStore(path string, ttl time.Duration, codec ElementCodec, refresh bool) CacheStore {
+	return &_FileCacheStore{path: path, ttl: ttl, codec: codec, refresh: refresh}
+}
+
+func (this *_FileCacheStore) read() (*file_content, error) {
+	data, err := ioutil.ReadFile(this.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	content := &file_content{}
+	err = json.Unmarshal(data, content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache file '%s': %s", this.path, err)
+	}
+	return content, nil
+}
+
+func (this *_FileCacheStore) Load() ([]interface{}, error) {
+	if this.refresh || this.ttl <= 0 {
+		return nil, nil
+	}
+	content, err := this.read()
+	if content == nil || err != nil {
+		return nil, err
+	}
+	if time.Since(content.Timestamp) > this.ttl {
+		return nil, nil
+	}
+	elems := make([]interface{}, len(content.Elements))
+	for i, d := range content.Elements {
+		elems[i], err = this.codec.Decode(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache file '%s': %s", this.path, err)
+		}
+	}
+	return elems, nil
+}
+
+func (this *_FileCacheStore) Store(elems []interface{}) error {
+	if this.ttl <= 0 {
+		return nil
+	}
+	content := &file_content{Timestamp: time.Now(), Elements: make([]json.RawMessage, len(elems))}
+	for i, e := range elems {
+		v, err := this.codec.Encode(e)
+		if err != nil {
+			return err
+		}
+		content.Elements[i], err = json.Marshal(v)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(this.path), 0700)
+	if err != nil {
+		return fmt.Errorf("cannot create cache dir: %s", err)
+	}
+	tmp := this.path + ".tmp"
+	err = ioutil.WriteFile(tmp, data, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot write cache file: %s", err)
+	}
+	return os.Rename(tmp, this.path)
+}
+
+func (this *_FileCacheStore) Stat() (*CacheStoreInfo, error) {
+	content, err := this.read()
+	if err != nil {
+		return nil, err
+	}
+	info := &CacheStoreInfo{Path: this.path, TTL: this.ttl}
+	if content != nil {
+		info.Count = len(content.Elements)
+		info.Timestamp = content.Timestamp
+	}
+	return info, nil
+}
+
+func (this *_FileCacheStore) Clear() error {
+	err := os.Remove(this.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -1,9 +1,24 @@
 package data
 
+import (
+	"time"
+)
+
 type ProcessChain interface {
 	Map(m MappingFunction) ProcessChain
 	Filter(f FilterFunction) ProcessChain
+	MapWithError(m ErrorMappingFunction) ProcessChain
+	FilterWithError(f ErrorFilterFunction) ProcessChain
+	WithErrorPolicy(p ErrorPolicy) ProcessChain
+	WithTimeout(d time.Duration, f TimeoutFunction) ProcessChain
+	WithProgress(f ProgressFunction) ProcessChain
 	Sort(c CompareFunction) ProcessChain
+	GroupBy(k KeyFunction, a Aggregation) ProcessChain
+	Reduce(initial interface{}, r ReduceFunction) ProcessChain
+	Distinct(k KeyFunction) ProcessChain
+	Limit(n int) ProcessChain
+	Skip(n int) ProcessChain
+	Batch(n int) ProcessChain
 	WithPool(p ProcessorPool) ProcessChain
 	Unordered() ProcessChain
 	Parallel(n int) ProcessChain
@@ -36,9 +51,42 @@ func (this *_ProcessChain) Map(m MappingFunction) ProcessChain {
 func (this *_ProcessChain) Filter(f FilterFunction) ProcessChain {
 	return (&_ProcessChain{}).new(this, chain_filter(f))
 }
+func (this *_ProcessChain) MapWithError(m ErrorMappingFunction) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_error_map(m))
+}
+func (this *_ProcessChain) FilterWithError(f ErrorFilterFunction) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_error_filter(f))
+}
+func (this *_ProcessChain) WithErrorPolicy(p ErrorPolicy) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_error_policy(p))
+}
+func (this *_ProcessChain) WithTimeout(d time.Duration, f TimeoutFunction) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_timeout(d, f))
+}
+func (this *_ProcessChain) WithProgress(f ProgressFunction) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_progress(f))
+}
 func (this *_ProcessChain) Sort(c CompareFunction) ProcessChain {
 	return (&_ProcessChain{}).new(this, chain_sort(c))
 }
+func (this *_ProcessChain) GroupBy(k KeyFunction, a Aggregation) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_group(k, a))
+}
+func (this *_ProcessChain) Reduce(initial interface{}, r ReduceFunction) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_reduce(initial, r))
+}
+func (this *_ProcessChain) Distinct(k KeyFunction) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_distinct(k))
+}
+func (this *_ProcessChain) Limit(n int) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_limit(n))
+}
+func (this *_ProcessChain) Skip(n int) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_skip(n))
+}
+func (this *_ProcessChain) Batch(n int) ProcessChain {
+	return (&_ProcessChain{}).new(this, chain_batch(n))
+}
 func (this *_ProcessChain) WithPool(p ProcessorPool) ProcessChain {
 	return (&_ProcessChain{}).new(this, chain_with_pool(p))
 }
@@ -69,9 +117,42 @@ func chain_map(m MappingFunction) chain_operation {
 func chain_filter(f FilterFunction) chain_operation {
 	return func(p ProcessingResult) ProcessingResult { return p.Filter(f) }
 }
+func chain_error_map(m ErrorMappingFunction) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.MapWithError(m) }
+}
+func chain_error_filter(f ErrorFilterFunction) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.FilterWithError(f) }
+}
+func chain_error_policy(e ErrorPolicy) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.WithErrorPolicy(e) }
+}
+func chain_timeout(d time.Duration, f TimeoutFunction) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.WithTimeout(d, f) }
+}
+func chain_progress(f ProgressFunction) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.WithProgress(f) }
+}
 func chain_sort(c CompareFunction) chain_operation {
 	return func(p ProcessingResult) ProcessingResult { return p.Sort(c) }
 }
+func chain_group(k KeyFunction, a Aggregation) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.GroupBy(k, a) }
+}
+func chain_reduce(initial interface{}, r ReduceFunction) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.Reduce(initial, r) }
+}
+func chain_distinct(k KeyFunction) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.Distinct(k) }
+}
+func chain_limit(n int) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.Limit(n) }
+}
+func chain_skip(n int) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.Skip(n) }
+}
+func chain_batch(n int) chain_operation {
+	return func(p ProcessingResult) ProcessingResult { return p.Batch(n) }
+}
 func chain_with_pool(pool ProcessorPool) chain_operation {
 	return func(p ProcessingResult) ProcessingResult { return p.WithPool(pool) }
 }
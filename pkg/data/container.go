@@ -255,7 +255,7 @@ type entry struct {
 
 ////////////////////////////////////////////////////////////////////////////
 
-func newEntryIterableFromIterable(data Iterable) entry_iterable {
+func newEntryIterableFromIterable(data Iterable, control *processing_control) entry_iterable {
 	e, ok := data.(entry_iterable)
 	if ok {
 		return e
@@ -265,7 +265,7 @@ func newEntryIterableFromIterable(data Iterable) entry_iterable {
 	go func() {
 
 		i := data.Iterator()
-		for idx := 0; i.HasNext(); idx++ {
+		for idx := 0; i.HasNext() && !control.cancelled(); idx++ {
 			c.add(entry{idx, true, i.Next()})
 		}
 		c.close()
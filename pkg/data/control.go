@@ -0,0 +1,232 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ErrorFilterFunction func(interface{}) (bool, error)
+type ErrorMappingFunction func(interface{}) (interface{}, error)
+
+// ErrorPolicy determines the handling of errors provided by
+// error aware mapping and filter functions.
+type ErrorPolicy int
+
+const (
+	// FIRST_ERROR cancels the complete processing on the first error.
+	FIRST_ERROR ErrorPolicy = iota
+	// COLLECT_ERRORS drops the failed elements and continues
+	// processing. All errors are reported at the end.
+	COLLECT_ERRORS
+)
+
+// ProcessingErrors is the error reported for the COLLECT_ERRORS policy.
+type ProcessingErrors []error
+
+func (this ProcessingErrors) Error() string {
+	msgs := make([]string, len(this))
+	for i, err := range this {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// TimeoutFunction provides the result for an element whose processing
+// timed out.
+type TimeoutFunction func(interface{}) interface{}
+
+// Progress describes the state of a processing aggregated over its
+// parallel steps. Total is the number of element operations scheduled
+// so far, it is final if Complete is set for all steps.
+type Progress struct {
+	Total    int
+	Done     int
+	TimedOut int
+	Complete bool
+}
+
+type ProgressFunction func(Progress)
+
+////////////////////////////////////////////////////////////////////////////
+
+// processing_control is shared by all steps of a processing. It
+// offers the cancellation context and gathers the processing errors.
+type processing_control struct {
+	lock   sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	policy ErrorPolicy
+	errors []error
+
+	timeout   time.Duration
+	ontimeout TimeoutFunction
+	progress  ProgressFunction
+
+	steps_lock sync.Mutex
+	steps      []*Progress
+}
+
+func (this *processing_control) new(ctx context.Context) *processing_control {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	this.ctx, this.cancel = context.WithCancel(ctx)
+	return this
+}
+
+func (this *processing_control) Context() context.Context {
+	return this.ctx
+}
+
+// Cancel stops all steps of the processing. Elements already
+// processed are still provided.
+func (this *processing_control) Cancel() {
+	this.cancel()
+}
+
+// Error provides the processing error according to the error policy.
+// For a cancelled processing without element errors the cancellation
+// reason is returned. It is complete after the iteration of the
+// processing result is finished.
+func (this *processing_control) Error() error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if len(this.errors) == 0 {
+		return this.ctx.Err()
+	}
+	if this.policy == FIRST_ERROR {
+		return this.errors[0]
+	}
+	return append(ProcessingErrors{}, this.errors...)
+}
+
+func (this *processing_control) cancelled() bool {
+	return this.ctx.Err() != nil
+}
+
+func (this *processing_control) setPolicy(p ErrorPolicy) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.policy = p
+}
+
+func (this *processing_control) setTimeout(d time.Duration, f TimeoutFunction) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.timeout = d
+	this.ontimeout = f
+}
+
+func (this *processing_control) setProgress(f ProgressFunction) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.progress = f
+}
+
+func (this *processing_control) getProgress() ProgressFunction {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.progress
+}
+
+// new_step provides the progress state of a new parallel step.
+func (this *processing_control) new_step() *Progress {
+	this.steps_lock.Lock()
+	defer this.steps_lock.Unlock()
+	p := &Progress{}
+	this.steps = append(this.steps, p)
+	return p
+}
+
+// report updates the progress of a step and passes the progress
+// aggregated over all steps to the progress function.
+func (this *processing_control) report(step *Progress, update func(p *Progress)) {
+	this.steps_lock.Lock()
+	defer this.steps_lock.Unlock()
+	update(step)
+	f := this.getProgress()
+	if f == nil {
+		return
+	}
+	total := Progress{Complete: true}
+	for _, p := range this.steps {
+		total.Total += p.Total
+		total.Done += p.Done
+		total.TimedOut += p.TimedOut
+		total.Complete = total.Complete && p.Complete
+	}
+	f(total)
+}
+
+func (this *processing_control) error(err error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.policy == FIRST_ERROR {
+		if len(this.errors) == 0 {
+			this.errors = append(this.errors, err)
+		}
+		this.cancel()
+		return
+	}
+	this.errors = append(this.errors, err)
+}
+
+// apply executes an operation on an element according to the
+// state of the processing.
+func (this *processing_control) apply(op operation, e interface{}) (interface{}, bool) {
+	v, ok, _, _ := this.applyTimed(op, e)
+	return v, ok
+}
+
+type op_result struct {
+	value interface{}
+	ok    bool
+	err   error
+}
+
+// applyTimed executes an operation obeying the configured timeout.
+// A timed out operation is left running, its result is replaced
+// by the one of the timeout function, if set, or dropped.
+// For an operation still running the returned channel is closed
+// when it finally returns. The caller must keep the resources used
+// for the operation, e.g. its pool slot, until then.
+func (this *processing_control) applyTimed(op operation, e interface{}) (interface{}, bool, bool, <-chan struct{}) {
+	if this.cancelled() {
+		return nil, false, false, nil
+	}
+	this.lock.Lock()
+	timeout, ontimeout := this.timeout, this.ontimeout
+	this.lock.Unlock()
+
+	var r op_result
+	if timeout <= 0 {
+		r.value, r.ok, r.err = op.process(e)
+	} else {
+		c := make(chan op_result, 1)
+		pending := make(chan struct{})
+		go func() {
+			v, ok, err := op.process(e)
+			c <- op_result{v, ok, err}
+			close(pending)
+		}()
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case r = <-c:
+		case <-this.ctx.Done():
+			return nil, false, false, pending
+		case <-timer.C:
+			if ontimeout != nil {
+				return ontimeout(e), true, true, pending
+			}
+			return nil, false, true, pending
+		}
+	}
+	if r.err != nil {
+		this.error(r.err)
+		return nil, false, false, nil
+	}
+	return r.value, r.ok, false, nil
+}
@@ -62,6 +62,11 @@ func (this IndexedSliceAccess) Sort(cmp CompareFunction) IndexedSliceAccess {
 	return this
 }
 
+func (this IndexedSliceAccess) SortStable(cmp CompareFunction) IndexedSliceAccess {
+	SortStable(this, cmp)
+	return this
+}
+
 func (this IndexedSliceAccess) entry_iterator() entry_iterator {
 	return (&_slice_entry_iterator{}).new(this)
 }
@@ -3,11 +3,13 @@ package data
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
 var log = false
 
 type _ParallelProcessing struct {
+	*processing_control
 	data    entry_iterable
 	pool    ProcessorPool
 	creator container_creator
@@ -15,7 +17,8 @@ type _ParallelProcessing struct {
 
 var _ Iterable = &_ParallelProcessing{}
 
-func (this *_ParallelProcessing) new(data entry_iterable, pool ProcessorPool, creator container_creator) *_ParallelProcessing {
+func (this *_ParallelProcessing) new(data entry_iterable, pool ProcessorPool, creator container_creator, control *processing_control) *_ParallelProcessing {
+	this.processing_control = control
 	this.data = data
 	this.pool = pool
 	this.creator = creator
@@ -23,28 +26,86 @@ func (this *_ParallelProcessing) new(data entry_iterable, pool ProcessorPool, cr
 }
 
 func (this *_ParallelProcessing) Map(m MappingFunction) ProcessingResult {
-	return (&_ParallelStep{}).new(this.pool, this.data, mapper(m), this.creator)
+	return (&_ParallelStep{}).new(this.pool, this.data, mapper(m), this.creator, this.processing_control)
 }
 func (this *_ParallelProcessing) Filter(f FilterFunction) ProcessingResult {
-	return (&_ParallelStep{}).new(this.pool, this.data, filter(f), this.creator)
+	return (&_ParallelStep{}).new(this.pool, this.data, filter(f), this.creator, this.processing_control)
+}
+func (this *_ParallelProcessing) MapWithError(m ErrorMappingFunction) ProcessingResult {
+	return (&_ParallelStep{}).new(this.pool, this.data, error_mapper(m), this.creator, this.processing_control)
+}
+func (this *_ParallelProcessing) FilterWithError(f ErrorFilterFunction) ProcessingResult {
+	return (&_ParallelStep{}).new(this.pool, this.data, error_filter(f), this.creator, this.processing_control)
+}
+func (this *_ParallelProcessing) WithErrorPolicy(p ErrorPolicy) ProcessingResult {
+	this.setPolicy(p)
+	return this
+}
+func (this *_ParallelProcessing) WithTimeout(d time.Duration, f TimeoutFunction) ProcessingResult {
+	this.setTimeout(d, f)
+	return this
+}
+func (this *_ParallelProcessing) WithProgress(f ProgressFunction) ProcessingResult {
+	this.setProgress(f)
+	return this
 }
 func (this *_ParallelProcessing) Sort(c CompareFunction) ProcessingResult {
 	setup := func() Iterable { return this.AsSlice().Sort(c) }
 	fmt.Printf("POOL %+v\n", this.pool)
-	return (&_ParallelProcessing{}).new(NewAsyncProcessingSource(setup, this.pool).(entry_iterable), this.pool, NewOrderedContainer)
+	return (&_ParallelProcessing{}).new(NewAsyncProcessingSource(setup, this.pool).(entry_iterable), this.pool, NewOrderedContainer, this.processing_control)
+}
+
+// GroupBy groups the elements and executes the group aggregations in
+// parallel.
+func (this *_ParallelProcessing) GroupBy(k KeyFunction, a Aggregation) ProcessingResult {
+	groups := this.barrier(func(data Iterable, control *processing_control) Iterable {
+		return group(data, k, control)
+	})
+	return groups.Map(aggregate(a))
+}
+func (this *_ParallelProcessing) Reduce(initial interface{}, r ReduceFunction) ProcessingResult {
+	return this.barrier(process_reduce(initial, r))
+}
+func (this *_ParallelProcessing) Distinct(k KeyFunction) ProcessingResult {
+	return this.barrier(process_distinct(k))
+}
+func (this *_ParallelProcessing) Limit(n int) ProcessingResult {
+	return this.barrier(process_range(0, n))
+}
+func (this *_ParallelProcessing) Skip(n int) ProcessingResult {
+	return this.barrier(process_range(n, -1))
+}
+func (this *_ParallelProcessing) Batch(n int) ProcessingResult {
+	return this.barrier(process_batch(n))
+}
+
+// barrier executes a processing requiring the complete (ordered) input
+// and continues parallel processing on its result.
+// The processing waits for the preceding steps executed by the pool,
+// therefore it runs in a separate goroutine not occupying a pool slot.
+func (this *_ParallelProcessing) barrier(proc processing) *_ParallelProcessing {
+	p := NewIncrementalProcessingSource()
+	go func() {
+		i := proc(this, this.processing_control).Iterator()
+		for i.HasNext() {
+			p.Add(i.Next())
+		}
+		p.Close()
+	}()
+	return (&_ParallelProcessing{}).new(p.(entry_iterable), this.pool, NewOrderedContainer, this.processing_control)
 }
 
 func (this *_ParallelProcessing) WithPool(p ProcessorPool) ProcessingResult {
-	return (&_ParallelProcessing{}).new(this.data, p, this.creator)
+	return (&_ParallelProcessing{}).new(this.data, p, this.creator, this.processing_control)
 }
 func (this *_ParallelProcessing) Parallel(n int) ProcessingResult {
 	return this.WithPool(NewProcessorPool(n))
 }
 func (this *_ParallelProcessing) Synchronously() ProcessingResult {
-	return (&_SynchronousProcessing{}).new(this)
+	return (&_SynchronousProcessing{}).new(this, this.processing_control)
 }
 func (this *_ParallelProcessing) Asynchronously() ProcessingResult {
-	return (&_AsynchronousProcessing{}).new(this)
+	return (&_AsynchronousProcessing{}).new(this, this.processing_control)
 }
 func (this *_ParallelProcessing) Unordered() ProcessingResult {
 	data := this.data
@@ -52,7 +113,7 @@ func (this *_ParallelProcessing) Unordered() ProcessingResult {
 	if ok {
 		data = &ordered._container
 	}
-	return (&_ParallelProcessing{}).new(data, this.pool, NewContainer)
+	return (&_ParallelProcessing{}).new(data, this.pool, NewContainer, this.processing_control)
 }
 func (this *_ParallelProcessing) Apply(p ProcessChain) ProcessingResult {
 	return p.Process(this)
@@ -72,11 +133,18 @@ type _ParallelStep struct {
 	container container
 	op        operation
 	create    container_creator
+	state     *Progress
 }
 
-func (this *_ParallelStep) new(pool ProcessorPool, data entry_iterable, op operation, creator container_creator) *_ParallelStep {
+// report updates the progress of the step.
+func (this *_ParallelStep) report(update func(p *Progress)) {
+	this.processing_control.report(this.state, update)
+}
+
+func (this *_ParallelStep) new(pool ProcessorPool, data entry_iterable, op operation, creator container_creator, control *processing_control) *_ParallelStep {
 	this.container = creator()
-	this._ParallelProcessing.new(this.container, pool, creator)
+	this._ParallelProcessing.new(this.container, pool, creator, control)
+	this.state = control.new_step()
 	go func() {
 		if log {
 			fmt.Printf("start processing\n")
@@ -86,23 +154,44 @@ func (this *_ParallelStep) new(pool ProcessorPool, data entry_iterable, op opera
 		var wg sync.WaitGroup
 		for i.HasNext() {
 			e := i.next()
+			if control.cancelled() {
+				break
+			}
 			if log {
 				fmt.Printf("start %d\n", e.index)
 			}
 			wg.Add(1)
-			pool.Exec(func() {
+			this.report(func(p *Progress) { p.Total++ })
+			exec := pool.Exec
+			if kp, ok := pool.(KeyedProcessorPool); ok {
+				value := e.value
+				exec = func(f func()) { kp.ExecFor(value, f) }
+			}
+			exec(func() {
 				if log {
 					fmt.Printf("process %d\n", e.index)
 				}
-				e.value, e.ok = op.process(e.value)
+				var timedout bool
+				var pending <-chan struct{}
+				e.value, e.ok, timedout, pending = control.applyTimed(op, e.value)
 				this.container.add(e)
+				this.report(func(p *Progress) {
+					p.Done++
+					if timedout {
+						p.TimedOut++
+					}
+				})
 				if log {
 					fmt.Printf("done %d\n", e.index)
 				}
 				wg.Done()
-
+				if pending != nil {
+					// keep the pool slot until the operation returns
+					<-pending
+				}
 			})
 		}
+		this.report(func(p *Progress) { p.Complete = true })
 		wg.Wait()
 		this.pool.Release()
 		this.container.close()
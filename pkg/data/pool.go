@@ -95,3 +95,140 @@ func (this *processor_set) exec(f func()) {
 func (this *processor_set) stop() {
 	close(this.request)
 }
+
+/////////////////////////////////////////////////////////////////////////////
+
+// KeyedProcessorPool is a processor pool limiting the number of parallel
+// tasks globally and per key. The key of a task is determined by the
+// processed element. Pending tasks with higher key priority are started
+// first.
+type KeyedProcessorPool interface {
+	ProcessorPool
+	ExecFor(elem interface{}, f func())
+	SetKeyLimit(key interface{}, n int) KeyedProcessorPool
+	SetKeyPriority(key interface{}, prio int) KeyedProcessorPool
+}
+
+type keyed_task struct {
+	key  interface{}
+	prio int
+	seq  int
+	f    func()
+}
+
+type _KeyedProcessorPool struct {
+	lock     sync.Mutex
+	n        int
+	perkey   int
+	key      KeyFunction
+	limits   map[interface{}]int
+	prios    map[interface{}]int
+	active   int
+	keyed    map[interface{}]int
+	pending  []*keyed_task
+	sequence int
+}
+
+var _ KeyedProcessorPool = &_KeyedProcessorPool{}
+
+// NewKeyedProcessorPool creates a pool running at most n tasks in
+// parallel and at most perkey tasks for the same key. A limit less
+// or equal to zero means unlimited.
+func NewKeyedProcessorPool(n int, perkey int, key KeyFunction) KeyedProcessorPool {
+	return (&_KeyedProcessorPool{}).new(n, perkey, key)
+}
+
+func (this *_KeyedProcessorPool) new(n int, perkey int, key KeyFunction) *_KeyedProcessorPool {
+	this.n = n
+	this.perkey = perkey
+	this.key = key
+	this.limits = map[interface{}]int{}
+	this.prios = map[interface{}]int{}
+	this.keyed = map[interface{}]int{}
+	return this
+}
+
+func (this *_KeyedProcessorPool) SetKeyLimit(key interface{}, n int) KeyedProcessorPool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.limits[key] = n
+	return this
+}
+
+func (this *_KeyedProcessorPool) SetKeyPriority(key interface{}, prio int) KeyedProcessorPool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.prios[key] = prio
+	return this
+}
+
+func (this *_KeyedProcessorPool) Request() {
+}
+func (this *_KeyedProcessorPool) Release() {
+}
+
+func (this *_KeyedProcessorPool) Exec(f func()) {
+	this.exec(nil, f)
+}
+
+func (this *_KeyedProcessorPool) ExecFor(elem interface{}, f func()) {
+	var key interface{}
+	if this.key != nil {
+		key = this.key(elem)
+	}
+	this.exec(key, f)
+}
+
+func (this *_KeyedProcessorPool) exec(key interface{}, f func()) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.sequence++
+	this.pending = append(this.pending, &keyed_task{key, this.prios[key], this.sequence, f})
+	this.schedule()
+}
+
+func (this *_KeyedProcessorPool) limit(key interface{}) int {
+	if key == nil {
+		return 0
+	}
+	if n, ok := this.limits[key]; ok {
+		return n
+	}
+	return this.perkey
+}
+
+// schedule starts pending tasks as long as the limits permit.
+// It must be called with the lock held.
+func (this *_KeyedProcessorPool) schedule() {
+	for this.n <= 0 || this.active < this.n {
+		found := -1
+		for i, t := range this.pending {
+			if l := this.limit(t.key); l > 0 && this.keyed[t.key] >= l {
+				continue
+			}
+			if found < 0 || t.prio > this.pending[found].prio ||
+				(t.prio == this.pending[found].prio && t.seq < this.pending[found].seq) {
+				found = i
+			}
+		}
+		if found < 0 {
+			return
+		}
+		t := this.pending[found]
+		this.pending = append(this.pending[:found], this.pending[found+1:]...)
+		this.active++
+		this.keyed[t.key]++
+		go this.run(t)
+	}
+}
+
+func (this *_KeyedProcessorPool) run(t *keyed_task) {
+	defer func() {
+		this.lock.Lock()
+		defer this.lock.Unlock()
+		this.active--
+		this.keyed[t.key]--
+		this.schedule()
+	}()
+	t.f()
+}
@@ -1,7 +1,9 @@
 package data
 
 import (
+	"context"
 	"sync"
+	"time"
 )
 
 type IncrementalProcessingSource interface {
@@ -25,9 +27,30 @@ type ProcessingResult interface {
 
 	Map(m MappingFunction) ProcessingResult
 	Filter(f FilterFunction) ProcessingResult
+	MapWithError(m ErrorMappingFunction) ProcessingResult
+	FilterWithError(f ErrorFilterFunction) ProcessingResult
 	Sort(c CompareFunction) ProcessingResult
+	GroupBy(k KeyFunction, a Aggregation) ProcessingResult
+	Reduce(initial interface{}, r ReduceFunction) ProcessingResult
+	Distinct(k KeyFunction) ProcessingResult
+	Limit(n int) ProcessingResult
+	Skip(n int) ProcessingResult
+	Batch(n int) ProcessingResult
 	Apply(c ProcessChain) ProcessingResult
 
+	// WithErrorPolicy sets the error policy for the complete processing.
+	WithErrorPolicy(p ErrorPolicy) ProcessingResult
+	// WithTimeout limits the processing time of elements in parallel
+	// steps. Timed out elements are replaced by the result of the
+	// given function or dropped if it is nil.
+	WithTimeout(d time.Duration, f TimeoutFunction) ProcessingResult
+	// WithProgress sets a function called for progress changes of
+	// parallel steps.
+	WithProgress(f ProgressFunction) ProcessingResult
+	Context() context.Context
+	Cancel()
+	Error() error
+
 	Synchronously() ProcessingResult
 	Asynchronously() ProcessingResult
 	WithPool(ProcessorPool) ProcessingResult
@@ -38,40 +61,68 @@ type ProcessingResult interface {
 }
 
 func Process(data Iterable) ProcessingResult {
-	return (&_SynchronousProcessing{}).new(data)
+	return ProcessWithContext(nil, data)
+}
+
+// ProcessWithContext starts a processing, which is cancelled
+// together with the given context.
+func ProcessWithContext(ctx context.Context, data Iterable) ProcessingResult {
+	return (&_SynchronousProcessing{}).new(data, (&processing_control{}).new(ctx))
 }
 
 ////////////////////////////////////////////////////////////////////////////
 
 type operation interface {
-	process(e interface{}) (interface{}, bool)
+	process(e interface{}) (interface{}, bool, error)
 }
 
 type mapper MappingFunction
 
-func (this mapper) process(e interface{}) (interface{}, bool) {
-	return this(e), true
+func (this mapper) process(e interface{}) (interface{}, bool, error) {
+	return this(e), true, nil
 }
 
 type filter FilterFunction
 
-func (this filter) process(e interface{}) (interface{}, bool) {
+func (this filter) process(e interface{}) (interface{}, bool, error) {
 	if this(e) {
-		return e, true
+		return e, true, nil
+	}
+	return nil, false, nil
+}
+
+type error_mapper ErrorMappingFunction
+
+func (this error_mapper) process(e interface{}) (interface{}, bool, error) {
+	v, err := this(e)
+	if err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+type error_filter ErrorFilterFunction
+
+func (this error_filter) process(e interface{}) (interface{}, bool, error) {
+	ok, err := this(e)
+	if err != nil || !ok {
+		return nil, false, err
 	}
-	return nil, false
+	return e, true, nil
 }
 
 /////////////////////////////////////////////////////////////////////////////
 
 type _SynchronousProcessing struct {
+	*processing_control
 	data Iterable
 }
 
 var _ Iterable = &_SynchronousProcessing{}
 
-func (this *_SynchronousProcessing) new(data Iterable) *_SynchronousProcessing {
+func (this *_SynchronousProcessing) new(data Iterable, control *processing_control) *_SynchronousProcessing {
 	this.data = data
+	this.processing_control = control
 	return this
 }
 
@@ -81,11 +132,47 @@ func (this *_SynchronousProcessing) Map(m MappingFunction) ProcessingResult {
 func (this *_SynchronousProcessing) Filter(f FilterFunction) ProcessingResult {
 	return (&_SynchronousStep{}).new(this, process(filter(f)))
 }
+func (this *_SynchronousProcessing) MapWithError(m ErrorMappingFunction) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process(error_mapper(m)))
+}
+func (this *_SynchronousProcessing) FilterWithError(f ErrorFilterFunction) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process(error_filter(f)))
+}
 func (this *_SynchronousProcessing) Sort(c CompareFunction) ProcessingResult {
 	return (&_SynchronousStep{}).new(this, process_sort(c))
 }
+func (this *_SynchronousProcessing) GroupBy(k KeyFunction, a Aggregation) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process_group(k, a))
+}
+func (this *_SynchronousProcessing) Reduce(initial interface{}, r ReduceFunction) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process_reduce(initial, r))
+}
+func (this *_SynchronousProcessing) Distinct(k KeyFunction) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process_distinct(k))
+}
+func (this *_SynchronousProcessing) Limit(n int) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process_range(0, n))
+}
+func (this *_SynchronousProcessing) Skip(n int) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process_range(n, -1))
+}
+func (this *_SynchronousProcessing) Batch(n int) ProcessingResult {
+	return (&_SynchronousStep{}).new(this, process_batch(n))
+}
+func (this *_SynchronousProcessing) WithErrorPolicy(p ErrorPolicy) ProcessingResult {
+	this.setPolicy(p)
+	return this
+}
+func (this *_SynchronousProcessing) WithTimeout(d time.Duration, f TimeoutFunction) ProcessingResult {
+	this.setTimeout(d, f)
+	return this
+}
+func (this *_SynchronousProcessing) WithProgress(f ProgressFunction) ProcessingResult {
+	this.setProgress(f)
+	return this
+}
 func (this *_SynchronousProcessing) WithPool(p ProcessorPool) ProcessingResult {
-	return (&_ParallelProcessing{}).new(newEntryIterableFromIterable(this.data), p, NewOrderedContainer)
+	return (&_ParallelProcessing{}).new(newEntryIterableFromIterable(this.data, this.processing_control), p, NewOrderedContainer, this.processing_control)
 }
 func (this *_SynchronousProcessing) Parallel(n int) ProcessingResult {
 	return this.WithPool(NewProcessorPool(n))
@@ -94,7 +181,7 @@ func (this *_SynchronousProcessing) Synchronously() ProcessingResult {
 	return this
 }
 func (this *_SynchronousProcessing) Asynchronously() ProcessingResult {
-	return (&_AsynchronousProcessing{}).new(this)
+	return (&_AsynchronousProcessing{}).new(this, this.processing_control)
 }
 func (this *_SynchronousProcessing) Unordered() ProcessingResult {
 	return this
@@ -116,24 +203,27 @@ type _SynchronousStep struct {
 	_SynchronousProcessing
 }
 
-func (this *_SynchronousStep) new(data Iterable, proc processing) *_SynchronousStep {
-	this.data = proc(data)
+func (this *_SynchronousStep) new(data *_SynchronousProcessing, proc processing) *_SynchronousStep {
+	this.processing_control = data.processing_control
+	this.data = proc(data, this.processing_control)
 	return this
 }
 
 /////////////////////////////////////////////////////////////////////////////
 
-type processing func(Iterable) Iterable
+type processing func(Iterable, *processing_control) Iterable
 
 type _AsynchronousProcessing struct {
+	*processing_control
 	data Iterable
 	lock sync.Mutex
 }
 
 var _ Iterable = &_AsynchronousProcessing{}
 
-func (this *_AsynchronousProcessing) new(data Iterable) *_AsynchronousProcessing {
+func (this *_AsynchronousProcessing) new(data Iterable, control *processing_control) *_AsynchronousProcessing {
 	this.data = data
+	this.processing_control = control
 	return this
 }
 
@@ -143,17 +233,53 @@ func (this *_AsynchronousProcessing) Map(m MappingFunction) ProcessingResult {
 func (this *_AsynchronousProcessing) Filter(f FilterFunction) ProcessingResult {
 	return (&_AsynchronousStep{}).new(this, process(filter(f)))
 }
+func (this *_AsynchronousProcessing) MapWithError(m ErrorMappingFunction) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process(error_mapper(m)))
+}
+func (this *_AsynchronousProcessing) FilterWithError(f ErrorFilterFunction) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process(error_filter(f)))
+}
 func (this *_AsynchronousProcessing) Sort(c CompareFunction) ProcessingResult {
 	return (&_AsynchronousStep{}).new(this, process_sort(c))
 }
+func (this *_AsynchronousProcessing) GroupBy(k KeyFunction, a Aggregation) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process_group(k, a))
+}
+func (this *_AsynchronousProcessing) Reduce(initial interface{}, r ReduceFunction) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process_reduce(initial, r))
+}
+func (this *_AsynchronousProcessing) Distinct(k KeyFunction) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process_distinct(k))
+}
+func (this *_AsynchronousProcessing) Limit(n int) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process_range(0, n))
+}
+func (this *_AsynchronousProcessing) Skip(n int) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process_range(n, -1))
+}
+func (this *_AsynchronousProcessing) Batch(n int) ProcessingResult {
+	return (&_AsynchronousStep{}).new(this, process_batch(n))
+}
+func (this *_AsynchronousProcessing) WithErrorPolicy(p ErrorPolicy) ProcessingResult {
+	this.setPolicy(p)
+	return this
+}
+func (this *_AsynchronousProcessing) WithTimeout(d time.Duration, f TimeoutFunction) ProcessingResult {
+	this.setTimeout(d, f)
+	return this
+}
+func (this *_AsynchronousProcessing) WithProgress(f ProgressFunction) ProcessingResult {
+	this.setProgress(f)
+	return this
+}
 func (this *_AsynchronousProcessing) WithPool(p ProcessorPool) ProcessingResult {
-	return (&_ParallelProcessing{}).new(newEntryIterableFromIterable(this.data), p, NewOrderedContainer)
+	return (&_ParallelProcessing{}).new(newEntryIterableFromIterable(this, this.processing_control), p, NewOrderedContainer, this.processing_control)
 }
 func (this *_AsynchronousProcessing) Parallel(n int) ProcessingResult {
 	return this.WithPool(NewProcessorPool(n))
 }
 func (this *_AsynchronousProcessing) Synchronously() ProcessingResult {
-	return (&_SynchronousProcessing{}).new(this)
+	return (&_SynchronousProcessing{}).new(this, this.processing_control)
 }
 func (this *_AsynchronousProcessing) Asynchronously() ProcessingResult {
 	return this
@@ -180,10 +306,11 @@ type _AsynchronousStep struct {
 	_AsynchronousProcessing
 }
 
-func (this *_AsynchronousStep) new(data Iterable, proc processing) *_AsynchronousStep {
+func (this *_AsynchronousStep) new(data *_AsynchronousProcessing, proc processing) *_AsynchronousStep {
+	this.processing_control = data.processing_control
 	this.lock.Lock()
 	go func() {
-		this.data = proc(data)
+		this.data = proc(data, this.processing_control)
 		this.lock.Unlock()
 	}()
 
@@ -192,8 +319,8 @@ func (this *_AsynchronousStep) new(data Iterable, proc processing) *_Asynchronou
 
 ////////////////////////////////////////////////////////////////////////////
 
-func process_sort(c CompareFunction) func(data Iterable) Iterable {
-	return func(data Iterable) Iterable {
+func process_sort(c CompareFunction) processing {
+	return func(data Iterable, control *processing_control) Iterable {
 		slice := Slice(data)
 		Sort(slice, c)
 		return IndexedSliceAccess(slice)
@@ -201,11 +328,11 @@ func process_sort(c CompareFunction) func(data Iterable) Iterable {
 }
 
 func process(op operation) processing {
-	return func(data Iterable) Iterable {
+	return func(data Iterable, control *processing_control) Iterable {
 		slice := []interface{}{}
 		i := data.Iterator()
-		for i.HasNext() {
-			e, ok := op.process(i.Next())
+		for i.HasNext() && !control.cancelled() {
+			e, ok := control.apply(op, i.Next())
 			if ok {
 				slice = append(slice, e)
 			}
@@ -16,3 +16,8 @@ func (a elements) Less(i, j int) bool { return a.compare(a.data[i], a.data[j]) <
 func Sort(data []interface{}, cmp CompareFunction) {
 	sort.Sort(&elements{data, cmp})
 }
+
+// SortStable sorts keeping the order of equal elements.
+func SortStable(data []interface{}, cmp CompareFunction) {
+	sort.Stable(&elements{data, cmp})
+}
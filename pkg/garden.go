@@ -3,15 +3,20 @@ package gube
 import (
 	"fmt"
 
+	v1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	restclient "k8s.io/client-go/rest"
 
 	_ "github.com/afritzler/garden-examiner/pkg/data"
 )
 
 type Garden interface {
+	GetName() string
 	NewWrapper(g Garden) Garden
 	GetShoots() (map[ShootName]Shoot, error)
 	GetShoot(*ShootName) (Shoot, error)
+	WatchShoots() (watch.Interface, error)
 	GetSeeds() (map[string]Seed, error)
 	GetSeed(name string) (Seed, error)
 	GetProjects() (map[string]Project, error)
@@ -19,11 +24,20 @@ type Garden interface {
 	GetProjectByNamespace(namespace string) (Project, error)
 	GetProfiles() (map[string]Profile, error)
 	GetProfile(name string) (Profile, error)
+	GetSecretBindings(namespace string) ([]v1beta1.SecretBinding, error)
+
+	// List methods provide the elements selected by list options,
+	// they are always read from the garden.
+	ListShoots(opts metav1.ListOptions) (map[ShootName]Shoot, error)
+	ListSeeds(opts metav1.ListOptions) (map[string]Seed, error)
+	ListProjects(opts metav1.ListOptions) (map[string]Project, error)
+	ListProfiles(opts metav1.ListOptions) (map[string]Profile, error)
 	Cluster
 }
 
 type garden struct {
 	cluster
+	name      string
 	access    *garden_access
 	effective Garden
 }
@@ -39,12 +53,17 @@ func NewGarden(config *restclient.Config) (Garden, error) {
 }
 
 func NewGardenFromConfigfile(configfile string) (Garden, error) {
+	return NewNamedGardenFromConfigfile("", configfile)
+}
+
+func NewNamedGardenFromConfigfile(name string, configfile string) (Garden, error) {
 	access, err := newGardenAccessFromConfigfile(configfile)
 	if err != nil {
 		return nil, err
 	}
-	return (&garden{}).new(access, nil), nil
-
+	g := (&garden{}).new(access, nil)
+	g.name = name
+	return g, nil
 }
 
 func NewGardenFromCBytes(bytes []byte) (Garden, error) {
@@ -67,8 +86,14 @@ func (g *garden) new(access *garden_access, eff Garden) *garden {
 	return g
 }
 
+func (this *garden) GetName() string {
+	return this.name
+}
+
 func (this *garden) NewWrapper(g Garden) Garden {
-	return (&garden{}).new(this.access, g)
+	w := (&garden{}).new(this.access, g)
+	w.name = this.name
+	return w
 }
 
 func (this *garden) GetKubeconfig() ([]byte, error) {
@@ -83,6 +108,14 @@ func (this *garden) GetShoots() (map[ShootName]Shoot, error) {
 	return this.access.GetShoots(this.effective)
 }
 
+func (this *garden) ListShoots(opts metav1.ListOptions) (map[ShootName]Shoot, error) {
+	return this.access.ListShoots(this.effective, opts)
+}
+
+func (this *garden) WatchShoots() (watch.Interface, error) {
+	return this.access.WatchShoots()
+}
+
 func (this *garden) GetShoot(name *ShootName) (Shoot, error) {
 	// fmt.Printf("shoot %s for garden %p %T(%p)\n", *name, this, this.effective, this.effective)
 	return this.access.GetShoot(this.effective, name)
@@ -92,6 +125,10 @@ func (this *garden) GetSeeds() (map[string]Seed, error) {
 	return this.access.GetSeeds(this.effective)
 }
 
+func (this *garden) ListSeeds(opts metav1.ListOptions) (map[string]Seed, error) {
+	return this.access.ListSeeds(this.effective, opts)
+}
+
 func (this *garden) GetSeed(name string) (Seed, error) {
 	return this.access.GetSeed(this.effective, name)
 }
@@ -100,6 +137,10 @@ func (this *garden) GetProjects() (map[string]Project, error) {
 	return this.access.GetProjects(this.effective)
 }
 
+func (this *garden) ListProjects(opts metav1.ListOptions) (map[string]Project, error) {
+	return this.access.ListProjects(this.effective, opts)
+}
+
 func (this *garden) GetProject(name string) (Project, error) {
 	return this.access.GetProject(this.effective, name)
 }
@@ -112,7 +153,15 @@ func (this *garden) GetProfiles() (map[string]Profile, error) {
 	return this.access.GetProfiles(this.effective)
 }
 
+func (this *garden) ListProfiles(opts metav1.ListOptions) (map[string]Profile, error) {
+	return this.access.ListProfiles(this.effective, opts)
+}
+
 func (this *garden) GetProfile(name string) (Profile, error) {
 	// fmt.Printf("profile %s for garden %p %T(%p)\n", name, this, this.effective, this.effective)
 	return this.access.GetProfile(this.effective, name)
 }
+
+func (this *garden) GetSecretBindings(namespace string) ([]v1beta1.SecretBinding, error) {
+	return this.access.GetSecretBindings(namespace)
+}
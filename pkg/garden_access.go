@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	v1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
 	gardenclientset "github.com/gardener/gardener/pkg/client/garden/clientset/versioned"
 	"github.com/gardener/gardener/pkg/operation/common"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 )
@@ -60,7 +62,11 @@ func (this *garden_access) GetKubeconfig() []byte {
 }
 
 func (this *garden_access) GetShoots(eff Garden) (map[ShootName]Shoot, error) {
-	shoots, err := this.gardenset.GardenV1beta1().Shoots("").List(metav1.ListOptions{})
+	return this.ListShoots(eff, metav1.ListOptions{})
+}
+
+func (this *garden_access) ListShoots(eff Garden, opts metav1.ListOptions) (map[ShootName]Shoot, error) {
+	shoots, err := this.gardenset.GardenV1beta1().Shoots("").List(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shoots: %s", err)
 	}
@@ -76,6 +82,20 @@ func (this *garden_access) GetShoots(eff Garden) (map[ShootName]Shoot, error) {
 	return result, nil
 }
 
+// WatchShoots watches the shoots of all projects starting with their
+// actual state, so only changes are reported.
+func (this *garden_access) WatchShoots() (watch.Interface, error) {
+	list, err := this.gardenset.GardenV1beta1().Shoots("").List(metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shoots: %s", err)
+	}
+	w, err := this.gardenset.GardenV1beta1().Shoots("").Watch(metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch shoots: %s", err)
+	}
+	return w, nil
+}
+
 func (this *garden_access) GetShoot(eff Garden, name *ShootName) (Shoot, error) {
 	project, err := eff.GetProject(name.GetProjectName())
 	if err != nil {
@@ -89,7 +109,11 @@ func (this *garden_access) GetShoot(eff Garden, name *ShootName) (Shoot, error)
 }
 
 func (this *garden_access) GetSeeds(eff Garden) (map[string]Seed, error) {
-	seeds, err := this.gardenset.GardenV1beta1().Seeds().List(metav1.ListOptions{})
+	return this.ListSeeds(eff, metav1.ListOptions{})
+}
+
+func (this *garden_access) ListSeeds(eff Garden, opts metav1.ListOptions) (map[string]Seed, error) {
+	seeds, err := this.gardenset.GardenV1beta1().Seeds().List(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seeds: %s", err)
 	}
@@ -110,9 +134,18 @@ func (this *garden_access) GetSeed(eff Garden, name string) (Seed, error) {
 }
 
 func (this *garden_access) GetProjects(eff Garden) (map[string]Project, error) {
-	namespaces, err := this.kubeset.CoreV1().Namespaces().List(metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("%s=%s", common.GardenRole, common.GardenRoleProject),
-	})
+	return this.ListProjects(eff, metav1.ListOptions{})
+}
+
+// ListProjects lists the project namespaces, a given label selector
+// is added to the selection of project namespaces.
+func (this *garden_access) ListProjects(eff Garden, opts metav1.ListOptions) (map[string]Project, error) {
+	selector := fmt.Sprintf("%s=%s", common.GardenRole, common.GardenRoleProject)
+	if opts.LabelSelector != "" {
+		selector += "," + opts.LabelSelector
+	}
+	opts.LabelSelector = selector
+	namespaces, err := this.kubeset.CoreV1().Namespaces().List(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project namespaces: %s", err)
 	}
@@ -147,7 +180,11 @@ func (this *garden_access) GetProjectByNamespace(eff Garden, namespace string) (
 }
 
 func (this *garden_access) GetProfiles(eff Garden) (map[string]Profile, error) {
-	elems, err := this.gardenset.GardenV1beta1().CloudProfiles().List(metav1.ListOptions{})
+	return this.ListProfiles(eff, metav1.ListOptions{})
+}
+
+func (this *garden_access) ListProfiles(eff Garden, opts metav1.ListOptions) (map[string]Profile, error) {
+	elems, err := this.gardenset.GardenV1beta1().CloudProfiles().List(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cloud profiles: %s", err)
 	}
@@ -168,6 +205,14 @@ func (this *garden_access) GetProfile(eff Garden, name string) (Profile, error)
 	return NewProfileFromProfileManifest(eff, *m), nil
 }
 
+func (this *garden_access) GetSecretBindings(namespace string) ([]v1beta1.SecretBinding, error) {
+	list, err := this.gardenset.GardenV1beta1().SecretBindings(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret bindings for namespace %s: %s", namespace, err)
+	}
+	return list.Items, nil
+}
+
 func (this *garden_access) GetSecretByRef(eff Garden, secretref corev1.SecretReference) (*corev1.Secret, error) {
 	secret, err := this.kubeset.CoreV1().Secrets(secretref.Namespace).Get(secretref.Name, metav1.GetOptions{})
 	if err != nil {
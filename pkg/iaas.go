@@ -11,6 +11,13 @@ type IaaSInfo interface {
 	GetKeyInfo() string
 }
 
+// NodeAccessInfo is optionally implemented by IaaS infos to describe
+// the ssh access to the nodes of a shoot.
+type NodeAccessInfo interface {
+	GetKeyName() string
+	GetNodesSecurityGroup() string
+}
+
 type _IaaSInfo struct {
 	kind            string
 	region          string
@@ -17,6 +17,7 @@ type AWSInfo struct {
 }
 
 var _ IaaSInfo = &AWSInfo{}
+var _ NodeAccessInfo = &AWSInfo{}
 
 func (this *AWSHandler) GetIaaSInfo(shoot Shoot) (IaaSInfo, error) {
 	info := &AWSInfo{_IaaSInfo: NewStandardIaaSInfo(shoot)}
@@ -44,3 +45,7 @@ func (this *AWSInfo) GetVpcId() string {
 func (this *AWSInfo) GetNodesSecurityGroupId() string {
 	return this.getInfraStringOutput("security_group_nodes")
 }
+
+func (this *AWSInfo) GetNodesSecurityGroup() string {
+	return this.GetNodesSecurityGroupId()
+}
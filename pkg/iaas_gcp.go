@@ -16,6 +16,7 @@ type GCPInfo struct {
 }
 
 var _ IaaSInfo = &GCPInfo{}
+var _ NodeAccessInfo = &GCPInfo{}
 
 func (this *GCPHandler) GetIaaSInfo(shoot Shoot) (IaaSInfo, error) {
 	info := &GCPInfo{_IaaSInfo: NewStandardIaaSInfo(shoot)}
@@ -39,3 +40,15 @@ func (this *GCPInfo) GetVpcName() string {
 func (this *GCPInfo) GetServiceAccountEMail() string {
 	return this.getInfraStringOutput("service_account_email")
 }
+
+// GetKeyName returns the empty string, because on GCP the ssh key is
+// propagated by instance metadata instead of a named key pair.
+func (this *GCPInfo) GetKeyName() string {
+	return ""
+}
+
+// GetNodesSecurityGroup provides the VPC, because firewall rules
+// are bound to the network on GCP.
+func (this *GCPInfo) GetNodesSecurityGroup() string {
+	return this.GetVpcName()
+}
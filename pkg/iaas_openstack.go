@@ -19,6 +19,7 @@ type OpenstackInfo struct {
 }
 
 var _ IaaSInfo = &OpenstackInfo{}
+var _ NodeAccessInfo = &OpenstackInfo{}
 
 func (this *OpenstackHandler) GetIaaSInfo(shoot Shoot) (IaaSInfo, error) {
 	info := &OpenstackInfo{_IaaSInfo: NewStandardIaaSInfo(shoot)}
@@ -87,3 +88,7 @@ func (this *OpenstackInfo) GetSubnetId() string {
 func (this *OpenstackInfo) GetSecurityGroupName() string {
 	return this.getInfraStringOutput("security_group_name")
 }
+
+func (this *OpenstackInfo) GetNodesSecurityGroup() string {
+	return this.GetSecurityGroupName()
+}
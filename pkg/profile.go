@@ -1,6 +1,8 @@
 package gube
 
 import (
+	"encoding/json"
+
 	. "github.com/afritzler/garden-examiner/pkg/data"
 
 	v1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
@@ -97,6 +99,27 @@ func (this *ProfileCacher) Key(elem interface{}) interface{} {
 	return elem.(Profile).GetName()
 }
 
+type ProfileCodec struct {
+	garden Garden
+}
+
+func NewProfileCodec(g Garden) ElementCodec {
+	return &ProfileCodec{g}
+}
+
+func (this *ProfileCodec) Encode(elem interface{}) (interface{}, error) {
+	return elem.(Profile).GetManifest(), nil
+}
+
+func (this *ProfileCodec) Decode(data []byte) (interface{}, error) {
+	m := v1beta1.CloudProfile{}
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+	return NewProfileFromProfileManifest(this.garden, m), nil
+}
+
 type ProfileCache interface {
 	GetProfiles() (map[string]Profile, error)
 	GetProfile(name string) (Profile, error)
@@ -111,6 +134,10 @@ func NewProfileCache(g Garden) ProfileCache {
 	return &profile_cache{NewCache(NewProfileCacher(g))}
 }
 
+func NewPersistentProfileCache(g Garden, store CacheStore) ProfileCache {
+	return &profile_cache{NewPersistentCache(NewProfileCacher(g), store)}
+}
+
 func (this *profile_cache) Reset() {
 	this.cache.Reset()
 }
@@ -1,8 +1,12 @@
 package gube
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/gardener/gardener/pkg/operation/common"
 	corev1 "k8s.io/api/core/v1"
 
 	. "github.com/afritzler/garden-examiner/pkg/data"
@@ -11,6 +15,10 @@ import (
 type Project interface {
 	GetName() string
 	GetNamespace() string
+	GetCreationTimestamp() time.Time
+	GetCreator() string
+	GetLabels() map[string]string
+	GetQuotas() ([]string, error)
 	GardenObject
 }
 
@@ -18,16 +26,23 @@ type project struct {
 	_GardenObject
 	name      string
 	namespace string
+	created   time.Time
+	creator   string
+	labels    map[string]string
 }
 
 func NewProjectFromNamespaceManifest(g Garden, n *corev1.Namespace) Project {
-	return (&project{}).new(g, GetProjectNameFromNamespaceManifest(n), n.GetName())
+	return (&project{}).new(g, GetProjectNameFromNamespaceManifest(n), n.GetName(),
+		n.GetCreationTimestamp().Time, n.GetAnnotations()[common.GardenCreatedBy], n.GetLabels())
 }
 
-func (p *project) new(g Garden, n string, ns string) Project {
+func (p *project) new(g Garden, n string, ns string, created time.Time, creator string, labels map[string]string) Project {
 	p._GardenObject.new(g)
 	p.name = n
 	p.namespace = ns
+	p.created = created
+	p.creator = creator
+	p.labels = labels
 	return p
 }
 func (p *project) GetName() string {
@@ -38,6 +53,46 @@ func (p *project) GetNamespace() string {
 	return p.namespace
 }
 
+func (p *project) GetCreationTimestamp() time.Time {
+	return p.created
+}
+
+// GetCreator provides the user that created the project
+// (createdBy annotation of the project namespace).
+func (p *project) GetCreator() string {
+	return p.creator
+}
+
+// GetLabels provides the labels of the project namespace.
+func (p *project) GetLabels() map[string]string {
+	return p.labels
+}
+
+// GetQuotas provides the quotas bound to the project by its secret
+// bindings. Quotas of other namespaces are prefixed by their namespace.
+func (p *project) GetQuotas() ([]string, error) {
+	bindings, err := p.garden.GetSecretBindings(p.namespace)
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]bool{}
+	quotas := []string{}
+	for _, b := range bindings {
+		for _, q := range b.Quotas {
+			name := q.Name
+			if q.Namespace != "" && q.Namespace != p.namespace {
+				name = q.Namespace + "/" + q.Name
+			}
+			if !found[name] {
+				found[name] = true
+				quotas = append(quotas, name)
+			}
+		}
+	}
+	sort.Strings(quotas)
+	return quotas, nil
+}
+
 //////////////////////////////////////////////////////////////////////////////
 // cache
 
@@ -70,6 +125,36 @@ func (this *ProjectCacher) Key(elem interface{}) interface{} {
 	return elem.(Project).GetName()
 }
 
+type ProjectCodec struct {
+	garden Garden
+}
+
+type project_data struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Created   time.Time         `json:"created"`
+	Creator   string            `json:"creator,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+func NewProjectCodec(g Garden) ElementCodec {
+	return &ProjectCodec{g}
+}
+
+func (this *ProjectCodec) Encode(elem interface{}) (interface{}, error) {
+	p := elem.(Project)
+	return &project_data{p.GetName(), p.GetNamespace(), p.GetCreationTimestamp(), p.GetCreator(), p.GetLabels()}, nil
+}
+
+func (this *ProjectCodec) Decode(data []byte) (interface{}, error) {
+	d := project_data{}
+	err := json.Unmarshal(data, &d)
+	if err != nil {
+		return nil, err
+	}
+	return (&project{}).new(this.garden, d.Name, d.Namespace, d.Created, d.Creator, d.Labels), nil
+}
+
 type ProjectCache interface {
 	GetProjects() (map[string]Project, error)
 	GetProject(name string) (Project, error)
@@ -86,6 +171,10 @@ func NewProjectCache(g Garden) ProjectCache {
 	return &project_cache{NewCache(NewProjectCacher(g)).(UnsyncedCache), map[string]Project{}}
 }
 
+func NewPersistentProjectCache(g Garden, store CacheStore) ProjectCache {
+	return &project_cache{NewPersistentCache(NewProjectCacher(g), store).(UnsyncedCache), map[string]Project{}}
+}
+
 func (this *project_cache) Reset() {
 	this.cache.Reset()
 	this.byNamespace = map[string]Project{}
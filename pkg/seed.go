@@ -79,6 +79,7 @@ func (s *seed) GetKubeconfig() ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get secret for seed %s: %s", s.name, err)
 	}
+	recordAccess(s, "kubeconfig", s.manifest.Spec.SecretRef.Name)
 	return secret.Data[secretkubeconfig], nil
 }
 
@@ -1,6 +1,7 @@
 package gube
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -212,6 +213,7 @@ func (s *shoot) GetKubeconfig() ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get secret for shoot '%s': %s", s.name, err)
 	}
+	recordAccess(s, "kubeconfig", ref.Name)
 	return secret.Data[secretkubeconfig], nil
 }
 
@@ -232,6 +234,7 @@ func (s *shoot) GetBasicAuth() (user, password string, err error) {
 	if err != nil {
 		return "", "", err
 	}
+	recordAccess(s, "basic-auth", "kubecfg")
 	user, ok := content["username"]
 	if !ok {
 		return "", "", fmt.Errorf("no user configured for shoot '%s'", s.GetName())
@@ -253,6 +256,7 @@ func (s *shoot) GetSecretContentFromSeed(name string) (map[string]string, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get secret '%s' for shoot '%s': %s", name, s.name, err)
 	}
+	recordAccess(s, "secret", name)
 	config := map[string]string{}
 	for k, v := range secret.Data {
 		config[k] = string(v)
@@ -419,6 +423,27 @@ func (this *ShootCacher) Key(elem interface{}) interface{} {
 	return *elem.(Shoot).GetName()
 }
 
+type ShootCodec struct {
+	garden Garden
+}
+
+func NewShootCodec(g Garden) ElementCodec {
+	return &ShootCodec{g}
+}
+
+func (this *ShootCodec) Encode(elem interface{}) (interface{}, error) {
+	return elem.(Shoot).GetManifest(), nil
+}
+
+func (this *ShootCodec) Decode(data []byte) (interface{}, error) {
+	m := v1beta1.Shoot{}
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+	return NewShootFromShootManifest(this.garden, m)
+}
+
 type ShootCache interface {
 	GetShoots() (map[ShootName]Shoot, error)
 	GetShoot(name *ShootName) (Shoot, error)
@@ -433,6 +458,10 @@ func NewShootCache(g Garden) ShootCache {
 	return &shoot_cache{NewCache(NewShootCacher(g))}
 }
 
+func NewPersistentShootCache(g Garden, store CacheStore) ShootCache {
+	return &shoot_cache{NewPersistentCache(NewShootCacher(g), store)}
+}
+
 func (this *shoot_cache) Reset() {
 	this.cache.Reset()
 }
@@ -2,6 +2,7 @@ package gube
 
 import (
 	"fmt"
+	"strings"
 
 	v1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -57,6 +58,39 @@ func NewShootName(project, name string) *ShootName {
 	return &ShootName{name, project}
 }
 
+// ParseShootName parses a shoot name of the form <project>/<name>.
+func ParseShootName(name string) (*ShootName, error) {
+	i := strings.Index(name, "/")
+	if i <= 0 || i == len(name)-1 {
+		return nil, fmt.Errorf("invalid shoot name '%s', expected <project>/<name>", name)
+	}
+	return NewShootName(name[:i], name[i+1:]), nil
+}
+
+// GARDEN_SEPARATOR separates the garden name from the element name
+// in fully qualified element names (<garden>:<project>/<shoot> or
+// <garden>:<seed>).
+const GARDEN_SEPARATOR = ":"
+
+// SplitQualifiedName splits a potentially fully qualified element name
+// into the garden name and the garden local element name.
+func SplitQualifiedName(name string) (string, string) {
+	i := strings.Index(name, GARDEN_SEPARATOR)
+	if i < 0 {
+		return "", name
+	}
+	return name[:i], name[i+1:]
+}
+
+// QualifiedName provides the fully qualified name for a garden local
+// element name.
+func QualifiedName(garden string, name string) string {
+	if garden == "" {
+		return name
+	}
+	return garden + GARDEN_SEPARATOR + name
+}
+
 func NewShootNameFromShootManifest(garden Garden, shoot v1beta1.Shoot) (*ShootName, error) {
 	p, err := garden.GetProjectByNamespace(shoot.GetNamespace())
 	if err != nil {